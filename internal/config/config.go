@@ -0,0 +1,949 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github_integration/internal/github"
+	"github_integration/internal/version"
+)
+
+// GitHub authentication modes
+const (
+	AuthModeToken = "token"
+	AuthModeApp   = "app"
+)
+
+// Config holds all service settings resolved from the environment
+type Config struct {
+	// GitHub settings
+	GitHubToken string
+	GitHubOrg   string
+
+	// GitHubAuthMode is "token" (personal access token) or "app" (GitHub App installation)
+	GitHubAuthMode       string
+	GitHubAppID          int64
+	GitHubInstallationID int64
+	GitHubAppPrivateKey  string
+
+	// Shared secret used to verify webhook signatures (verification is skipped when empty)
+	GitHubWebhookSecret string
+	// Accept legacy X-Hub-Signature (SHA-1) when X-Hub-Signature-256 is absent
+	AllowSHA1Signatures bool
+
+	// Interval between background GitHub token validity checks
+	GitHubTokenCheckInterval time.Duration
+	// Total tries for a GitHub API call that fails transiently (5xx, secondary rate limit)
+	GitHubMaxAttempts int
+	// How long repository details are cached before refetching
+	GitHubRepoCacheTTL time.Duration
+	// Fetch PR details with one GraphQL query instead of three REST calls
+	GitHubPRDetailsGraphQL bool
+	// On a 404 for a PR, check whether the token can see the repository at all, reporting missing
+	// access (private repo not granted to the token or app) separately from a deleted PR
+	GitHubDiagnoseNotFound bool
+
+	// Proxy for all outbound API traffic, overriding HTTPS_PROXY/HTTP_PROXY (NO_PROXY still applies)
+	APIProxyURL string
+	// User-Agent sent on GitHub and Jira API requests (defaults to github-jira-integration/<version>)
+	UserAgent string
+
+	// HTTP server settings
+	Port string
+	// Path prefix every route is served under (e.g. /github-jira behind a shared ingress); empty for none
+	RoutePrefix string
+	// Server-wide read/write timeouts; webhook routes use WebhookWriteTimeout instead
+	HTTPReadTimeout     time.Duration
+	HTTPWriteTimeout    time.Duration
+	WebhookWriteTimeout time.Duration
+	// Public URL of the webhook endpoint, registered on newly created repositories
+	WebhookPublicURL string
+	// Repo name pattern -> events subscribed when registering its webhook (e.g. {"template-*":
+	// ["pull_request"]}); the longest matching pattern wins, unmatched repos get the full default list
+	WebhookRepoEvents map[string][]string
+	// How often failed webhook registrations are retried, and how many attempts are made before
+	// giving up and alerting
+	WebhookRetryInterval    time.Duration
+	WebhookRetryMaxAttempts int
+	// JSON file keeping the failed webhook registrations across restarts (in memory when empty)
+	WebhookPendingFile string
+	// JSON file keeping the owners of repositories transferred out of the org (in memory when empty)
+	RepoOwnersFile string
+	// Largest accepted webhook payload in bytes
+	WebhookMaxBodyBytes int64
+	// Most webhook requests handled at once (0 = unlimited); extra deliveries get 503 + Retry-After
+	WebhookMaxInFlight int
+	WebhookRetryAfter  time.Duration
+	// Process deliveries on a worker pool and answer 202 immediately
+	WebhookAsync     bool
+	WebhookWorkers   int
+	WebhookQueueSize int
+	// Overall deadline for processing one delivery across all its API calls and retries (0 = none);
+	// deliveries that overrun it are abandoned and dead-lettered
+	EventProcessingTimeout time.Duration
+
+	// Per-event-type switches; a disabled delivery is acknowledged but neither queued, processed
+	// nor published. HandleIssues is reserved: no issues handler exists yet, so it only keeps issues
+	// deliveries out of the event stream (digest, event log)
+	HandlePush        bool
+	HandlePullRequest bool
+	HandleIssues      bool
+	// Emit debug log lines, e.g. for disabled deliveries
+	LogDebug bool
+
+	// Glob patterns (e.g. *.lock, vendor/**) for files left out of diffs and changed-file lists
+	DiffIgnoreGlobs []string
+
+	// Leave merge commits' diffs (the merge resolution) out of push reports
+	PushSkipMergeDiffs bool
+
+	// Pushes with at least this many commits are diffed with one compare call (0 disables)
+	PushCompareMinCommits int
+
+	// Bearer token for /admin endpoints (admin endpoints are disabled when empty)
+	AdminToken string
+	// Key for HMAC-signed, time-limited admin tokens (see "admin-token"); also enables admin endpoints
+	AdminSigningKey string
+
+	// JSON-lines file recording failed Jira operations for replay (disabled when empty)
+	DeadLetterFile string
+
+	// Machine-readable event log: "file", "s3" or empty to disable
+	EventLogSink string
+	// Local event log path, rotated past EventLogMaxBytes keeping EventLogBackups old files
+	EventLogFile     string
+	EventLogMaxBytes int
+	EventLogBackups  int
+	// S3 event log destination; EventLogS3Endpoint targets S3-compatible stores
+	EventLogS3Bucket        string
+	EventLogS3Region        string
+	EventLogS3Prefix        string
+	EventLogS3Endpoint      string
+	EventLogS3FlushInterval time.Duration
+	AWSAccessKeyID          string
+	AWSSecretAccessKey      string
+	AWSSessionToken         string
+
+	// Slack incoming webhook for operational alerts (alerts are only logged when empty)
+	SlackWebhookURL string
+	// Send an alert for every delivery whose processing failed
+	AlertOnFailedEvents bool
+
+	// Local time ("HH:MM") to post a daily activity summary (disabled when empty), to Slack
+	// and/or as a comment on a Jira dashboard issue; it is built from the event log (EVENT_LOG_SINK)
+	DailySummaryTime      string
+	DailySummarySlack     bool
+	DailySummaryJiraIssue string
+
+	// At startup, move the issues of PRs merged in the last StartupCatchUpWindow in these repos to the
+	// merged status, recovering merges delivered while the service was down
+	StartupCatchUp       bool
+	StartupCatchUpRepos  []string
+	StartupCatchUpWindow time.Duration
+
+	// Jira settings
+	JiraBaseURL  string
+	JiraEmail    string
+	JiraAPIToken string
+
+	// Maximum simultaneous Jira API requests (0 means unlimited)
+	JiraMaxConcurrency int
+
+	// Project receiving PR issues and prefix for all integration labels (empty for none)
+	JiraProjectKey  string
+	JiraLabelPrefix string
+	// Also find PR issues by the bare pr-N labels used before labels were prefixed
+	JiraLegacyLabelLookup bool
+	// Changed-file path prefix to project key; a PR routes to the project its files fall under.
+	// PRs spanning several projects go to JiraProjectKey, or to each of them with JiraPathRouteAll.
+	JiraPathProjects map[string]string
+	JiraPathRouteAll bool
+
+	// File each repository's PR issues in a project derived from its name (falling back to
+	// JiraProjectKey when no valid key results): strip a prefix, take an acronym, or override per repo
+	JiraProjectFromRepo       bool
+	JiraProjectKeyStripPrefix []string
+	JiraProjectKeyAcronym     bool
+	JiraProjectKeyOverrides   map[string]string
+	// Epic link field ID ("parent" on newer Jira) and the epic used when a PR names none
+	JiraEpicLinkField string
+	JiraDefaultEpic   string
+	// Go template for PR issue summaries over the PR's fields and .Area, e.g. "[{{.RepoName}}] {{.PRTitle}}"
+	// (validated at startup, capped at 255 characters); the default is "PR #N: title"
+	JiraSummaryTemplate string
+	// Prefix issue summaries with the monorepo directory the PR's files share, down to this depth
+	JiraSummaryPathPrefix bool
+	JiraSummaryPathDepth  int
+	// Sprint custom field ID (e.g. customfield_10020) and the board whose active sprint new PR
+	// issues are added to; both are needed to enable sprint assignment
+	JiraSprintField   string
+	JiraSprintBoardID int
+	// Extra fields (JSON object of field ID to value) merged into every created issue
+	JiraFieldDefaults map[string]interface{}
+
+	// GitHub login or email to Jira account ID, used for assignee/reporter mapping
+	JiraUserMap map[string]string
+	// Look up a GitHub user's public email when neither their commit email nor login is mapped
+	JiraUserEmailLookup bool
+	// Report PR issues as the PR author's mapped Jira account (needs Modify Reporter permission)
+	JiraSetReporter bool
+
+	// Jira status to move an issue to, keyed by GitHub event then action.
+	// The pull_request "opened" entry is the status new issues start in; "merged"
+	// is a pseudo-action for a closed PR that was merged. deployment_status entries
+	// are keyed by deployment state, so a deploy-gated workflow maps "merged" to
+	// e.g. "Pending Deploy" and deployment_status "success" to "Done".
+	JiraTransitions map[string]map[string]string
+	// Allowed next statuses per status, e.g. {"Open_PR": ["Merged_PR", "Rejected"]}; moves
+	// from a listed status to anything else are refused. Unlisted statuses are unrestricted.
+	JiraStateMachine map[string][]string
+	// PR transitions arriving within this long of the PR's previous one are coalesced into the
+	// final state, applied when the window ends (0 applies every transition immediately)
+	JiraTransitionCooldown time.Duration
+
+	// Create, transition and delete a throwaway issue at startup to verify permissions end to end
+	JiraSelfTest bool
+
+	// Comment pushed commits on the Jira issue of the branch's open PR
+	JiraMirrorPushes bool
+	// Comment on the branch's PR issue when a force push rewrites its history
+	JiraCommentForcePush bool
+	// Comment on the PR issue when a review is requested or withdrawn; map the review_requested
+	// action in JIRA_TRANSITIONS to also move the issue (e.g. to "In Review")
+	JiraCommentReviewRequests bool
+	// Comment once on PRs that get no Jira issue (opted out, excluded target branch, too small) with the reason
+	JiraCommentSkipReason bool
+	// Keep one living comment with the CI outcome of completed check runs/suites on PR issues; map
+	// check_suite failure/success in JIRA_TRANSITIONS to also move the issue
+	JiraCIStatusComment bool
+	// When a merged PR's issue was deleted in Jira, recreate it directly in the merged status
+	JiraRecreateMissingOnMerge bool
+
+	// Run "/jira status <name>" and "/jira skip" commands posted by collaborators on PRs
+	JiraSlashCommands bool
+	// Mirror PR labels added or removed on GitHub onto the Jira issue (as <prefix>-label-<name>)
+	JiraSyncLabels bool
+
+	// Audit repository visibility changes as a comment on this issue, or else a new issue in this project
+	JiraSecurityIssue   string
+	JiraSecurityProject string
+
+	// Link new PR issues to Jira issues referenced in the PR title or body, with this link type
+	JiraLinkReferencedIssues bool
+	JiraIssueLinkType        string
+	// Link the issues of stacked PRs ("Depends on #12" in the body) with this link type
+	JiraLinkDependencies   bool
+	JiraDependencyLinkType string
+
+	// Name tagged invisibly on every comment the integration posts, so its own comments can be recognised
+	CommentMarker string
+
+	// Post the created Jira issue link back on the PR as a comment
+	CommentJiraLink bool
+
+	// Include the PR description in the Jira issue description
+	JiraIncludePRBody bool
+
+	// PR label that opts a PR out of Jira tracking, and the status an already-created
+	// issue is moved to when the label is added later (empty leaves the issue alone)
+	JiraOptOutLabel  string
+	JiraOptOutStatus string
+
+	// Status open PR issues are moved to when their repository is archived
+	JiraArchivedStatus string
+
+	// Mirror PR locks: move the issue to JiraLockedStatus with a comment giving the lock reason, and
+	// back to JiraUnlockedStatus (the open status when empty) on unlock
+	JiraSyncLocks      bool
+	JiraLockedStatus   string
+	JiraUnlockedStatus string
+
+	// Create a sub-task per component (path prefix -> component name) under each PR issue, and move
+	// them to JiraSubTaskCloseStatus when the PR closes
+	JiraSubTaskMode        bool
+	JiraSubTaskComponents  map[string]string
+	JiraSubTaskType        string
+	JiraSubTaskCloseStatus string
+
+	// Default issue type of PR issues, and repo topic -> issue type overrides (first matching topic wins)
+	JiraIssueType       string
+	JiraTopicIssueTypes map[string]string
+	// Conventional commit type -> issue type (e.g. {"feat": "Story", "fix": "Bug"}), read from the
+	// PR title or else its first commit; takes precedence over topic overrides
+	JiraCommitTypeIssueTypes map[string]string
+
+	// Jira labels added for changed file extensions, e.g. {".go": "lang:go", ".tsx": "area:frontend"}
+	JiraExtensionLabels map[string]string
+
+	// GitHub team slug -> Jira component; PR issues get the components of the teams owning the repo
+	JiraTeamComponents map[string]string
+
+	// Target branch globs (e.g. main, release/*) whose PRs get Jira issues; empty allows every branch
+	JiraTargetBranches []string
+
+	// Minimum PR size required before a Jira issue is created (0 disables the check)
+	JiraMinChangedFiles int
+	JiraMinChangedLines int
+
+	// Per-org overrides keyed by lower-cased org name, with DefaultProfile as the fallback
+	Profiles map[string]Profile
+}
+
+// commentMarkerPattern restricts COMMENT_MARKER to characters safe inside HTML comments and Jira macros
+var commentMarkerPattern = regexp.MustCompile(`^[\w.-]+$`)
+
+// Load reads configuration from environment variables
+func Load() (*Config, error) {
+	cfg := &Config{
+		GitHubToken: os.Getenv("GITHUB_TOKEN"),
+		GitHubOrg:   os.Getenv("GITHUB_ORG"),
+
+		GitHubAuthMode:      getEnv("GITHUB_AUTH_MODE", AuthModeToken),
+		GitHubAppPrivateKey: os.Getenv("GITHUB_APP_PRIVATE_KEY_PATH"),
+		Port:                getEnv("PORT", "3000"),
+		WebhookPublicURL:    os.Getenv("WEBHOOK_PUBLIC_URL"),
+		APIProxyURL:         os.Getenv("API_PROXY_URL"),
+		UserAgent:           getEnv("USER_AGENT", version.DefaultUserAgent()),
+
+		JiraBaseURL: os.Getenv("JIRA_BASE_URL"),
+		JiraEmail:   os.Getenv("JIRA_EMAIL"),
+
+		JiraProjectKey:  getEnv("JIRA_PROJECT_KEY", "REP"),
+		JiraLabelPrefix: getEnv("JIRA_LABEL_PREFIX", "github"),
+
+		JiraEpicLinkField: os.Getenv("JIRA_EPIC_LINK_FIELD"),
+		JiraDefaultEpic:   os.Getenv("JIRA_DEFAULT_EPIC"),
+		JiraSprintField:   os.Getenv("JIRA_SPRINT_FIELD"),
+
+		JiraSummaryTemplate: os.Getenv("JIRA_SUMMARY_TEMPLATE"),
+
+		JiraOptOutLabel:  getEnv("JIRA_OPT_OUT_LABEL", "no-jira"),
+		JiraOptOutStatus: os.Getenv("JIRA_OPT_OUT_STATUS"),
+
+		JiraArchivedStatus: getEnv("JIRA_ARCHIVED_STATUS", "Done"),
+
+		JiraLockedStatus:   getEnv("JIRA_LOCKED_STATUS", "On Hold"),
+		JiraUnlockedStatus: os.Getenv("JIRA_UNLOCKED_STATUS"),
+
+		JiraIssueType: getEnv("JIRA_ISSUE_TYPE", "Task"),
+
+		JiraSubTaskType:        getEnv("JIRA_SUBTASK_ISSUE_TYPE", "Sub-task"),
+		JiraSubTaskCloseStatus: getEnv("JIRA_SUBTASK_CLOSE_STATUS", "Done"),
+
+		JiraIssueLinkType:      getEnv("JIRA_ISSUE_LINK_TYPE", "Relates"),
+		JiraDependencyLinkType: getEnv("JIRA_DEPENDENCY_LINK_TYPE", "Blocks"),
+		CommentMarker:          getEnv("COMMENT_MARKER", "jira-sync"),
+
+		DiffIgnoreGlobs: getEnvList("DIFF_IGNORE_GLOBS"),
+
+		JiraTargetBranches: getEnvList("JIRA_TARGET_BRANCHES"),
+
+		AdminToken:      os.Getenv("ADMIN_TOKEN"),
+		SlackWebhookURL: os.Getenv("SLACK_WEBHOOK_URL"),
+		DeadLetterFile:  os.Getenv("DEAD_LETTER_FILE"),
+
+		WebhookPendingFile: os.Getenv("WEBHOOK_PENDING_FILE"),
+		RepoOwnersFile:     os.Getenv("REPO_OWNERS_FILE"),
+
+		DailySummaryTime:      os.Getenv("DAILY_SUMMARY_TIME"),
+		DailySummaryJiraIssue: os.Getenv("DAILY_SUMMARY_JIRA_ISSUE"),
+
+		StartupCatchUpRepos: getEnvList("STARTUP_CATCHUP_REPOS"),
+
+		EventLogSink:       os.Getenv("EVENT_LOG_SINK"),
+		EventLogFile:       getEnv("EVENT_LOG_FILE", "events.jsonl"),
+		EventLogS3Bucket:   os.Getenv("EVENT_LOG_S3_BUCKET"),
+		EventLogS3Region:   getEnv("EVENT_LOG_S3_REGION", os.Getenv("AWS_REGION")),
+		EventLogS3Prefix:   os.Getenv("EVENT_LOG_S3_PREFIX"),
+		EventLogS3Endpoint: os.Getenv("EVENT_LOG_S3_ENDPOINT"),
+		AWSAccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+		AWSSessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+	}
+
+	var err error
+	if cfg.GitHubWebhookSecret, err = getEnvOrFile("GITHUB_WEBHOOK_SECRET"); err != nil {
+		return nil, err
+	}
+	if cfg.JiraAPIToken, err = getEnvOrFile("JIRA_API_TOKEN"); err != nil {
+		return nil, err
+	}
+	if cfg.AdminSigningKey, err = getEnvOrFile("ADMIN_SIGNING_KEY"); err != nil {
+		return nil, err
+	}
+	if cfg.AWSSecretAccessKey, err = getEnvOrFile("AWS_SECRET_ACCESS_KEY"); err != nil {
+		return nil, err
+	}
+
+	switch cfg.GitHubAuthMode {
+	case AuthModeToken:
+		if cfg.GitHubToken == "" || cfg.GitHubOrg == "" {
+			return nil, fmt.Errorf("GITHUB_TOKEN and GITHUB_ORG environment variables are required")
+		}
+	case AuthModeApp:
+		if cfg.GitHubAppID, err = getEnvInt64("GITHUB_APP_ID"); err != nil {
+			return nil, err
+		}
+		if cfg.GitHubInstallationID, err = getEnvInt64("GITHUB_APP_INSTALLATION_ID"); err != nil {
+			return nil, err
+		}
+		if cfg.GitHubAppPrivateKey == "" || cfg.GitHubOrg == "" {
+			return nil, fmt.Errorf("GITHUB_APP_PRIVATE_KEY_PATH and GITHUB_ORG environment variables are required in app auth mode")
+		}
+	default:
+		return nil, fmt.Errorf("invalid value for GITHUB_AUTH_MODE: %q (expected %q or %q)", cfg.GitHubAuthMode, AuthModeToken, AuthModeApp)
+	}
+
+	if cfg.JiraMinChangedFiles, err = getEnvInt("JIRA_MIN_CHANGED_FILES", 0); err != nil {
+		return nil, err
+	}
+	if cfg.JiraMinChangedLines, err = getEnvInt("JIRA_MIN_CHANGED_LINES", 0); err != nil {
+		return nil, err
+	}
+	if cfg.JiraMaxConcurrency, err = getEnvInt("JIRA_MAX_CONCURRENCY", 4); err != nil {
+		return nil, err
+	}
+
+	maxBody, err := getEnvInt("WEBHOOK_MAX_BODY_BYTES", 5<<20)
+	if err != nil {
+		return nil, err
+	}
+	if maxBody == 0 {
+		return nil, fmt.Errorf("invalid value for WEBHOOK_MAX_BODY_BYTES: must be positive")
+	}
+	cfg.WebhookMaxBodyBytes = int64(maxBody)
+
+	if cfg.DailySummarySlack, err = getEnvBool("DAILY_SUMMARY_SLACK", false); err != nil {
+		return nil, err
+	}
+	if cfg.StartupCatchUp, err = getEnvBool("STARTUP_CATCHUP", false); err != nil {
+		return nil, err
+	}
+	if cfg.StartupCatchUpWindow, err = getEnvDuration("STARTUP_CATCHUP_WINDOW", 24*time.Hour); err != nil {
+		return nil, err
+	}
+	if cfg.StartupCatchUp && len(cfg.StartupCatchUpRepos) == 0 {
+		return nil, fmt.Errorf("STARTUP_CATCHUP requires STARTUP_CATCHUP_REPOS")
+	}
+	if cfg.DailySummaryTime != "" {
+		if _, err := time.Parse("15:04", cfg.DailySummaryTime); err != nil {
+			return nil, fmt.Errorf("invalid value for DAILY_SUMMARY_TIME: %q (use HH:MM)", cfg.DailySummaryTime)
+		}
+		if !cfg.DailySummarySlack && cfg.DailySummaryJiraIssue == "" {
+			return nil, fmt.Errorf("DAILY_SUMMARY_TIME requires DAILY_SUMMARY_SLACK or DAILY_SUMMARY_JIRA_ISSUE")
+		}
+		if cfg.DailySummarySlack && cfg.SlackWebhookURL == "" {
+			return nil, fmt.Errorf("DAILY_SUMMARY_SLACK requires SLACK_WEBHOOK_URL")
+		}
+		if cfg.EventLogSink == "" {
+			return nil, fmt.Errorf("DAILY_SUMMARY_TIME requires EVENT_LOG_SINK: the summary is built from the event log")
+		}
+	}
+
+	switch cfg.EventLogSink {
+	case "", "file":
+	case "s3":
+		if cfg.EventLogS3Bucket == "" || cfg.EventLogS3Region == "" {
+			return nil, fmt.Errorf("EVENT_LOG_SINK=s3 requires EVENT_LOG_S3_BUCKET and EVENT_LOG_S3_REGION (or AWS_REGION)")
+		}
+	default:
+		return nil, fmt.Errorf("invalid value for EVENT_LOG_SINK: %q (use file or s3)", cfg.EventLogSink)
+	}
+	if cfg.EventLogMaxBytes, err = getEnvInt("EVENT_LOG_MAX_BYTES", 100<<20); err != nil {
+		return nil, err
+	}
+	if cfg.EventLogBackups, err = getEnvInt("EVENT_LOG_BACKUPS", 5); err != nil {
+		return nil, err
+	}
+	if cfg.EventLogS3FlushInterval, err = getEnvDuration("EVENT_LOG_S3_FLUSH_INTERVAL", time.Minute); err != nil {
+		return nil, err
+	}
+
+	if err = getEnvJSON("JIRA_FIELD_DEFAULTS", &cfg.JiraFieldDefaults); err != nil {
+		return nil, err
+	}
+	if err = getEnvJSON("JIRA_USER_MAP", &cfg.JiraUserMap); err != nil {
+		return nil, err
+	}
+	if cfg.JiraUserEmailLookup, err = getEnvBool("JIRA_USER_EMAIL_LOOKUP", false); err != nil {
+		return nil, err
+	}
+	if err = getEnvJSON("JIRA_TOPIC_ISSUE_TYPES", &cfg.JiraTopicIssueTypes); err != nil {
+		return nil, err
+	}
+	if err = getEnvJSON("JIRA_COMMIT_TYPE_ISSUE_TYPES", &cfg.JiraCommitTypeIssueTypes); err != nil {
+		return nil, err
+	}
+	if err = getEnvJSON("JIRA_EXTENSION_LABELS", &cfg.JiraExtensionLabels); err != nil {
+		return nil, err
+	}
+	if err = getEnvJSON("JIRA_TEAM_COMPONENTS", &cfg.JiraTeamComponents); err != nil {
+		return nil, err
+	}
+	for _, pattern := range cfg.JiraTargetBranches {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid JIRA_TARGET_BRANCHES pattern %q: %w", pattern, err)
+		}
+	}
+	if cfg.JiraIncludePRBody, err = getEnvBool("JIRA_INCLUDE_PR_BODY", false); err != nil {
+		return nil, err
+	}
+	// Unlike most settings, an explicitly empty JIRA_LABEL_PREFIX is honoured and turns the prefix off
+	if prefix, ok := os.LookupEnv("JIRA_LABEL_PREFIX"); ok {
+		cfg.JiraLabelPrefix = strings.TrimSpace(prefix)
+	}
+	if cfg.JiraLegacyLabelLookup, err = getEnvBool("JIRA_LEGACY_LABEL_LOOKUP", true); err != nil {
+		return nil, err
+	}
+	if cfg.JiraSubTaskMode, err = getEnvBool("JIRA_SUBTASK_MODE", false); err != nil {
+		return nil, err
+	}
+	if err = getEnvJSON("JIRA_SUBTASK_COMPONENTS", &cfg.JiraSubTaskComponents); err != nil {
+		return nil, err
+	}
+	if cfg.JiraSubTaskMode && len(cfg.JiraSubTaskComponents) == 0 {
+		return nil, fmt.Errorf("JIRA_SUBTASK_MODE requires JIRA_SUBTASK_COMPONENTS")
+	}
+	if err = getEnvJSON("JIRA_PATH_PROJECTS", &cfg.JiraPathProjects); err != nil {
+		return nil, err
+	}
+	if cfg.JiraProjectFromRepo, err = getEnvBool("JIRA_PROJECT_FROM_REPO", false); err != nil {
+		return nil, err
+	}
+	cfg.JiraProjectKeyStripPrefix = getEnvList("JIRA_PROJECT_KEY_STRIP_PREFIXES")
+	if cfg.JiraProjectKeyAcronym, err = getEnvBool("JIRA_PROJECT_KEY_ACRONYM", false); err != nil {
+		return nil, err
+	}
+	if err = getEnvJSON("JIRA_PROJECT_KEY_OVERRIDES", &cfg.JiraProjectKeyOverrides); err != nil {
+		return nil, err
+	}
+	if cfg.JiraPathRouteAll, err = getEnvBool("JIRA_PATH_ROUTE_ALL", false); err != nil {
+		return nil, err
+	}
+	cfg.JiraTransitions = defaultTransitions()
+	if err = getEnvJSON("JIRA_TRANSITIONS", &cfg.JiraTransitions); err != nil {
+		return nil, err
+	}
+	if err = getEnvJSON("JIRA_STATE_MACHINE", &cfg.JiraStateMachine); err != nil {
+		return nil, err
+	}
+	if cfg.JiraSetReporter, err = getEnvBool("JIRA_SET_REPORTER", false); err != nil {
+		return nil, err
+	}
+	if cfg.JiraSelfTest, err = getEnvBool("JIRA_SELFTEST", false); err != nil {
+		return nil, err
+	}
+	if cfg.JiraMirrorPushes, err = getEnvBool("JIRA_MIRROR_PUSHES", false); err != nil {
+		return nil, err
+	}
+	if cfg.JiraCommentForcePush, err = getEnvBool("JIRA_COMMENT_FORCE_PUSH", false); err != nil {
+		return nil, err
+	}
+	if cfg.JiraCommentReviewRequests, err = getEnvBool("JIRA_COMMENT_REVIEW_REQUESTS", false); err != nil {
+		return nil, err
+	}
+	if cfg.JiraCommentSkipReason, err = getEnvBool("JIRA_COMMENT_SKIP_REASON", false); err != nil {
+		return nil, err
+	}
+	if cfg.JiraCIStatusComment, err = getEnvBool("JIRA_CI_STATUS_COMMENT", false); err != nil {
+		return nil, err
+	}
+	if cfg.JiraSyncLocks, err = getEnvBool("JIRA_SYNC_LOCKS", false); err != nil {
+		return nil, err
+	}
+	if cfg.JiraLinkDependencies, err = getEnvBool("JIRA_LINK_DEPENDENCIES", false); err != nil {
+		return nil, err
+	}
+	if cfg.JiraRecreateMissingOnMerge, err = getEnvBool("JIRA_RECREATE_MISSING_ON_MERGE", false); err != nil {
+		return nil, err
+	}
+	if cfg.JiraSlashCommands, err = getEnvBool("JIRA_SLASH_COMMANDS", false); err != nil {
+		return nil, err
+	}
+	if cfg.JiraSyncLabels, err = getEnvBool("JIRA_SYNC_LABELS", false); err != nil {
+		return nil, err
+	}
+	cfg.JiraSecurityIssue = getEnv("JIRA_SECURITY_ISSUE", "")
+	cfg.JiraSecurityProject = getEnv("JIRA_SECURITY_PROJECT", "")
+	if !commentMarkerPattern.MatchString(cfg.CommentMarker) {
+		return nil, fmt.Errorf("invalid value for COMMENT_MARKER: %q (letters, digits, '.', '_' and '-' only)", cfg.CommentMarker)
+	}
+	if cfg.JiraLinkReferencedIssues, err = getEnvBool("JIRA_LINK_REFERENCED_ISSUES", false); err != nil {
+		return nil, err
+	}
+	if cfg.CommentJiraLink, err = getEnvBool("COMMENT_JIRA_LINK", false); err != nil {
+		return nil, err
+	}
+	if cfg.PushSkipMergeDiffs, err = getEnvBool("PUSH_SKIP_MERGE_DIFFS", false); err != nil {
+		return nil, err
+	}
+	if cfg.PushCompareMinCommits, err = getEnvInt("PUSH_COMPARE_MIN_COMMITS", 5); err != nil {
+		return nil, err
+	}
+	if cfg.WebhookAsync, err = getEnvBool("WEBHOOK_ASYNC", false); err != nil {
+		return nil, err
+	}
+	if cfg.HandlePush, err = getEnvBool("HANDLE_PUSH", true); err != nil {
+		return nil, err
+	}
+	if cfg.HandlePullRequest, err = getEnvBool("HANDLE_PULL_REQUEST", true); err != nil {
+		return nil, err
+	}
+	if cfg.HandleIssues, err = getEnvBool("HANDLE_ISSUES", true); err != nil {
+		return nil, err
+	}
+	if cfg.LogDebug, err = getEnvBool("LOG_DEBUG", false); err != nil {
+		return nil, err
+	}
+	if cfg.WebhookWorkers, err = getEnvInt("WEBHOOK_WORKERS", 4); err != nil {
+		return nil, err
+	}
+	if cfg.WebhookQueueSize, err = getEnvInt("WEBHOOK_QUEUE_SIZE", 100); err != nil {
+		return nil, err
+	}
+	if cfg.EventProcessingTimeout, err = getEnvOptionalDuration("EVENT_PROCESSING_TIMEOUT"); err != nil {
+		return nil, err
+	}
+	if cfg.WebhookAsync && cfg.WebhookWorkers == 0 {
+		return nil, fmt.Errorf("invalid value for WEBHOOK_WORKERS: must be positive when WEBHOOK_ASYNC is enabled")
+	}
+
+	if cfg.AlertOnFailedEvents, err = getEnvBool("ALERT_ON_FAILED_EVENTS", false); err != nil {
+		return nil, err
+	}
+	if cfg.AllowSHA1Signatures, err = getEnvBool("ALLOW_SHA1_SIGNATURES", false); err != nil {
+		return nil, err
+	}
+	if cfg.GitHubTokenCheckInterval, err = getEnvDuration("GITHUB_TOKEN_CHECK_INTERVAL", 5*time.Minute); err != nil {
+		return nil, err
+	}
+
+	if cfg.GitHubMaxAttempts, err = getEnvInt("GITHUB_MAX_ATTEMPTS", 3); err != nil {
+		return nil, err
+	}
+	if cfg.RoutePrefix = strings.TrimRight(os.Getenv("ROUTE_PREFIX"), "/"); cfg.RoutePrefix != "" {
+		if !strings.HasPrefix(cfg.RoutePrefix, "/") {
+			cfg.RoutePrefix = "/" + cfg.RoutePrefix
+		}
+		if strings.ContainsAny(cfg.RoutePrefix, "{}?#") {
+			return nil, fmt.Errorf("invalid value for ROUTE_PREFIX: %q", cfg.RoutePrefix)
+		}
+	}
+	if cfg.HTTPReadTimeout, err = getEnvDuration("HTTP_READ_TIMEOUT", 15*time.Second); err != nil {
+		return nil, err
+	}
+	if cfg.HTTPWriteTimeout, err = getEnvDuration("HTTP_WRITE_TIMEOUT", 15*time.Second); err != nil {
+		return nil, err
+	}
+	if cfg.WebhookWriteTimeout, err = getEnvDuration("WEBHOOK_WRITE_TIMEOUT", 2*time.Minute); err != nil {
+		return nil, err
+	}
+	if cfg.WebhookRetryInterval, err = getEnvDuration("WEBHOOK_RETRY_INTERVAL", 10*time.Minute); err != nil {
+		return nil, err
+	}
+	if cfg.WebhookRetryMaxAttempts, err = getEnvInt("WEBHOOK_RETRY_MAX_ATTEMPTS", 10); err != nil {
+		return nil, err
+	}
+	if cfg.WebhookRetryMaxAttempts < 1 {
+		return nil, fmt.Errorf("invalid value for WEBHOOK_RETRY_MAX_ATTEMPTS: must be at least 1")
+	}
+	if cfg.JiraTransitionCooldown, err = getEnvOptionalDuration("JIRA_TRANSITION_COOLDOWN"); err != nil {
+		return nil, err
+	}
+	if cfg.JiraSummaryPathPrefix, err = getEnvBool("JIRA_SUMMARY_PATH_PREFIX", false); err != nil {
+		return nil, err
+	}
+	if cfg.JiraSummaryPathDepth, err = getEnvInt("JIRA_SUMMARY_PATH_DEPTH", 1); err != nil {
+		return nil, err
+	}
+	if cfg.JiraSummaryPathPrefix && cfg.JiraSummaryPathDepth < 1 {
+		return nil, fmt.Errorf("invalid value for JIRA_SUMMARY_PATH_DEPTH: must be at least 1")
+	}
+	if cfg.JiraSprintBoardID, err = getEnvInt("JIRA_SPRINT_BOARD_ID", 0); err != nil {
+		return nil, err
+	}
+	if (cfg.JiraSprintField == "") != (cfg.JiraSprintBoardID == 0) {
+		return nil, fmt.Errorf("JIRA_SPRINT_FIELD and JIRA_SPRINT_BOARD_ID must be set together")
+	}
+	if err = getEnvJSON("WEBHOOK_REPO_EVENTS", &cfg.WebhookRepoEvents); err != nil {
+		return nil, err
+	}
+	for pattern, events := range cfg.WebhookRepoEvents {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid WEBHOOK_REPO_EVENTS pattern %q: %w", pattern, err)
+		}
+		if len(events) == 0 {
+			return nil, fmt.Errorf("invalid WEBHOOK_REPO_EVENTS entry %q: at least one event is required", pattern)
+		}
+	}
+	if cfg.WebhookMaxInFlight, err = getEnvInt("WEBHOOK_MAX_IN_FLIGHT", 0); err != nil {
+		return nil, err
+	}
+	if cfg.WebhookRetryAfter, err = getEnvDuration("WEBHOOK_RETRY_AFTER", 30*time.Second); err != nil {
+		return nil, err
+	}
+	if cfg.GitHubRepoCacheTTL, err = getEnvDuration("GITHUB_REPO_CACHE_TTL", 10*time.Minute); err != nil {
+		return nil, err
+	}
+	if cfg.GitHubPRDetailsGraphQL, err = getEnvBool("GITHUB_PR_DETAILS_GRAPHQL", false); err != nil {
+		return nil, err
+	}
+	if cfg.GitHubDiagnoseNotFound, err = getEnvBool("GITHUB_DIAGNOSE_NOT_FOUND", true); err != nil {
+		return nil, err
+	}
+
+	if path := os.Getenv("CONFIG_PROFILES_FILE"); path != "" {
+		if cfg.Profiles, err = loadProfiles(path); err != nil {
+			return nil, err
+		}
+	}
+
+	return cfg, nil
+}
+
+// AppAuth reports whether the service authenticates as a GitHub App
+func (c *Config) AppAuth() bool {
+	return c.GitHubAuthMode == AuthModeApp
+}
+
+// JiraEnabled reports whether enough Jira settings are present to build a client
+func (c *Config) JiraEnabled() bool {
+	return c.JiraBaseURL != "" && c.JiraEmail != "" && c.JiraAPIToken != ""
+}
+
+// EventEnabled reports whether deliveries of a GitHub event type should be processed
+func (c *Config) EventEnabled(eventType github.EventType) bool {
+	switch eventType {
+	case github.EventPush:
+		return c.HandlePush
+	case github.EventPullRequest:
+		return c.HandlePullRequest
+	case github.EventIssues:
+		return c.HandleIssues
+	}
+	return true
+}
+
+// RepoSettingsNaming returns the per-repository settings that name repoName literally, which
+// need updating by hand when the repository is renamed
+func (c *Config) RepoSettingsNaming(repoName string) []string {
+	var settings []string
+	if _, ok := c.JiraProjectKeyOverrides[repoName]; ok {
+		settings = append(settings, "JIRA_PROJECT_KEY_OVERRIDES")
+	}
+	if _, ok := c.WebhookRepoEvents[repoName]; ok {
+		settings = append(settings, "WEBHOOK_REPO_EVENTS")
+	}
+	for _, repo := range c.StartupCatchUpRepos {
+		if repo == repoName {
+			settings = append(settings, "STARTUP_CATCHUP_REPOS")
+			break
+		}
+	}
+	return settings
+}
+
+// WebhookEventsFor returns the events to subscribe a repository's webhook to, or nil for the
+// default list. The longest pattern matching the repo name wins.
+func (c *Config) WebhookEventsFor(repoName string) []string {
+	var best string
+	var events []string
+	for pattern, subset := range c.WebhookRepoEvents {
+		if ok, _ := path.Match(pattern, repoName); !ok {
+			continue
+		}
+		if events == nil || len(pattern) > len(best) || (len(pattern) == len(best) && pattern < best) {
+			best, events = pattern, subset
+		}
+	}
+	return events
+}
+
+// defaultTransitions is the built-in PR workflow: open on creation, merged on merge
+func defaultTransitions() map[string]map[string]string {
+	return map[string]map[string]string{
+		string(github.EventPullRequest): {
+			"opened": "Open_PR",
+			"merged": "Merged_PR",
+		},
+	}
+}
+
+// TransitionFor returns the configured Jira status for a GitHub event action
+func (c *Config) TransitionFor(event github.EventType, action string) (string, bool) {
+	status, ok := c.JiraTransitions[string(event)][action]
+	return status, ok && status != ""
+}
+
+// JiraAccountFor returns the Jira account ID mapped to a GitHub login or email
+func (c *Config) JiraAccountFor(login string) (string, bool) {
+	accountID, ok := c.JiraUserMap[login]
+	return accountID, ok && accountID != ""
+}
+
+// RoutedProjects returns the distinct projects named by the path routing map, sorted
+func (c *Config) RoutedProjects() []string {
+	seen := make(map[string]bool)
+	var projects []string
+	for _, project := range c.JiraPathProjects {
+		if project != "" && !seen[project] {
+			seen[project] = true
+			projects = append(projects, project)
+		}
+	}
+	sort.Strings(projects)
+	return projects
+}
+
+// TransitionStatuses returns every distinct status referenced by the transition map
+func (c *Config) TransitionStatuses() []string {
+	seen := make(map[string]bool)
+	var statuses []string
+	for _, actions := range c.JiraTransitions {
+		for _, status := range actions {
+			if status != "" && !seen[status] {
+				seen[status] = true
+				statuses = append(statuses, status)
+			}
+		}
+	}
+	sort.Strings(statuses)
+	return statuses
+}
+
+// getEnv returns the environment value for key or fallback when unset
+func getEnv(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
+
+// getEnvOrFile returns the environment value for key, or the trimmed contents of the
+// file named by key_FILE (e.g. a mounted Kubernetes or Docker secret); the variable wins if both are set
+func getEnvOrFile(key string) (string, error) {
+	if value := os.Getenv(key); value != "" {
+		return value, nil
+	}
+	path := os.Getenv(key + "_FILE")
+	if path == "" {
+		return "", nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s_FILE: %w", key, err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// getEnvInt parses an integer environment value, returning fallback when unset
+func getEnvInt(key string, fallback int) (int, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback, nil
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value for %s: %w", key, err)
+	}
+	if n < 0 {
+		return 0, fmt.Errorf("invalid value for %s: must not be negative", key)
+	}
+	return n, nil
+}
+
+// getEnvInt64 parses a required positive 64-bit integer environment value
+func getEnvInt64(key string) (int64, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return 0, fmt.Errorf("%s environment variable is required", key)
+	}
+
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value for %s: %w", key, err)
+	}
+	if n <= 0 {
+		return 0, fmt.Errorf("invalid value for %s: must be positive", key)
+	}
+	return n, nil
+}
+
+// getEnvBool parses a boolean environment value ("true", "1", "false", ...), returning fallback when unset
+func getEnvBool(key string, fallback bool) (bool, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback, nil
+	}
+
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("invalid value for %s: %w", key, err)
+	}
+	return b, nil
+}
+
+// getEnvList splits a comma-separated environment value, dropping empty entries
+func getEnvList(key string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// getEnvJSON decodes a JSON environment value into target, leaving it untouched when unset
+func getEnvJSON(key string, target interface{}) error {
+	value := os.Getenv(key)
+	if value == "" {
+		return nil
+	}
+	if err := json.Unmarshal([]byte(value), target); err != nil {
+		return fmt.Errorf("invalid value for %s: %w", key, err)
+	}
+	return nil
+}
+
+// getEnvDuration parses a positive Go duration (e.g. "30s", "5m"), returning fallback when unset
+func getEnvDuration(key string, fallback time.Duration) (time.Duration, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback, nil
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value for %s: %w", key, err)
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("invalid value for %s: must be positive", key)
+	}
+	return d, nil
+}
+
+// getEnvOptionalDuration reads a duration where 0 (the default) turns the feature off
+func getEnvOptionalDuration(key string) (time.Duration, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return 0, nil
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value for %s: %w", key, err)
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("invalid value for %s: must not be negative", key)
+	}
+	return d, nil
+}
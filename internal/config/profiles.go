@@ -0,0 +1,82 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// DefaultProfile is the profile key used for orgs without a profile of their own
+const DefaultProfile = "default"
+
+// Profile overrides per-org Jira settings; unset fields keep the environment value
+type Profile struct {
+	JiraProjectKey  string                       `json:"jira_project_key,omitempty"`
+	JiraLabelPrefix string                       `json:"jira_label_prefix,omitempty"`
+	JiraDefaultEpic string                       `json:"jira_default_epic,omitempty"`
+	JiraTransitions map[string]map[string]string `json:"jira_transitions,omitempty"`
+	// Size filters; a pointer distinguishes "unset" from an explicit 0
+	JiraMinChangedFiles *int `json:"jira_min_changed_files,omitempty"`
+	JiraMinChangedLines *int `json:"jira_min_changed_lines,omitempty"`
+}
+
+// loadProfiles reads a JSON file mapping org names (case-insensitive) to profiles
+func loadProfiles(path string) (map[string]Profile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CONFIG_PROFILES_FILE: %w", err)
+	}
+
+	var raw map[string]Profile
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("invalid JSON in CONFIG_PROFILES_FILE: %w", err)
+	}
+
+	profiles := make(map[string]Profile, len(raw))
+	for org, profile := range raw {
+		for name, value := range map[string]*int{
+			"jira_min_changed_files": profile.JiraMinChangedFiles,
+			"jira_min_changed_lines": profile.JiraMinChangedLines,
+		} {
+			if value != nil && *value < 0 {
+				return nil, fmt.Errorf("invalid %s in profile %q: must not be negative", name, org)
+			}
+		}
+		profiles[strings.ToLower(org)] = profile
+	}
+	return profiles, nil
+}
+
+// ForOrg returns the effective configuration for org: the org's profile, else the
+// default profile, applied over the environment settings. It returns c itself when
+// no profile applies.
+func (c *Config) ForOrg(org string) *Config {
+	profile, ok := c.Profiles[strings.ToLower(org)]
+	if !ok {
+		if profile, ok = c.Profiles[DefaultProfile]; !ok {
+			return c
+		}
+	}
+
+	scoped := *c
+	if profile.JiraProjectKey != "" {
+		scoped.JiraProjectKey = profile.JiraProjectKey
+	}
+	if profile.JiraLabelPrefix != "" {
+		scoped.JiraLabelPrefix = profile.JiraLabelPrefix
+	}
+	if profile.JiraDefaultEpic != "" {
+		scoped.JiraDefaultEpic = profile.JiraDefaultEpic
+	}
+	if profile.JiraTransitions != nil {
+		scoped.JiraTransitions = profile.JiraTransitions
+	}
+	if profile.JiraMinChangedFiles != nil {
+		scoped.JiraMinChangedFiles = *profile.JiraMinChangedFiles
+	}
+	if profile.JiraMinChangedLines != nil {
+		scoped.JiraMinChangedLines = *profile.JiraMinChangedLines
+	}
+	return &scoped
+}
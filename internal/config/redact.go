@@ -0,0 +1,27 @@
+package config
+
+// Redacted returns a copy of the configuration with secrets masked, safe to expose for debugging
+func (c *Config) Redacted() *Config {
+	redacted := *c
+	redacted.GitHubToken = redact(c.GitHubToken)
+	redacted.GitHubWebhookSecret = redact(c.GitHubWebhookSecret)
+	redacted.JiraAPIToken = redact(c.JiraAPIToken)
+	redacted.AdminToken = redact(c.AdminToken)
+	redacted.AdminSigningKey = redact(c.AdminSigningKey)
+	redacted.AWSSecretAccessKey = redact(c.AWSSecretAccessKey)
+	redacted.AWSSessionToken = redact(c.AWSSessionToken)
+	redacted.SlackWebhookURL = redact(c.SlackWebhookURL)
+	redacted.APIProxyURL = redact(c.APIProxyURL) // may embed proxy credentials
+	return &redacted
+}
+
+// redact masks a secret, keeping only the last four characters of long values
+func redact(secret string) string {
+	if secret == "" {
+		return ""
+	}
+	if len(secret) <= 8 {
+		return "****"
+	}
+	return "****" + secret[len(secret)-4:]
+}
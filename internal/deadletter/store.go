@@ -0,0 +1,178 @@
+package deadletter
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// ErrNotFound is returned when no record has the requested ID
+var ErrNotFound = errors.New("dead-letter record not found")
+
+// Record is a failed operation with enough context to replay the originating webhook
+type Record struct {
+	ID        string          `json:"id"`
+	Operation string          `json:"operation"`
+	EventType string          `json:"event_type"`
+	Endpoint  string          `json:"endpoint"`
+	Repo      string          `json:"repo"`
+	PRNumber  int             `json:"pr_number,omitempty"`
+	Payload   json.RawMessage `json:"payload"`
+	Error     string          `json:"error"`
+	Timestamp time.Time       `json:"timestamp"`
+	Attempts  int             `json:"attempts"`
+}
+
+// Store persists dead-lettered operations
+type Store interface {
+	Add(record Record) error
+	List() ([]Record, error)
+	Get(id string) (Record, error)
+	Update(record Record) error
+	Remove(id string) error
+}
+
+// FileStore keeps records as JSON lines in a single file
+type FileStore struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFileStore creates a store backed by path, creating the file if needed
+func NewFileStore(path string) (*FileStore, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open dead-letter file: %w", err)
+	}
+	f.Close()
+	return &FileStore{path: path}, nil
+}
+
+// Add appends a record
+func (s *FileStore) Add(record Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	line, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to encode dead-letter record: %w", err)
+	}
+
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open dead-letter file: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write dead-letter record: %w", err)
+	}
+	return nil
+}
+
+// List returns all records, oldest first
+func (s *FileStore) List() ([]Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.read()
+}
+
+// Get returns the record with the given ID
+func (s *FileStore) Get(id string) (Record, error) {
+	records, err := s.List()
+	if err != nil {
+		return Record{}, err
+	}
+	for _, record := range records {
+		if record.ID == id {
+			return record, nil
+		}
+	}
+	return Record{}, ErrNotFound
+}
+
+// Update replaces the stored record with the same ID
+func (s *FileStore) Update(record Record) error {
+	return s.rewrite(record.ID, &record)
+}
+
+// Remove deletes the record with the given ID
+func (s *FileStore) Remove(id string) error {
+	return s.rewrite(id, nil)
+}
+
+// rewrite replaces (or drops, when replacement is nil) the record with id
+func (s *FileStore) rewrite(id string, replacement *Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	records, err := s.read()
+	if err != nil {
+		return err
+	}
+
+	found := false
+	kept := records[:0]
+	for _, record := range records {
+		if record.ID != id {
+			kept = append(kept, record)
+			continue
+		}
+		found = true
+		if replacement != nil {
+			kept = append(kept, *replacement)
+		}
+	}
+	if !found {
+		return ErrNotFound
+	}
+
+	// Write to a temp file and rename so a crash never leaves a half-written store
+	tmp := s.path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to rewrite dead-letter file: %w", err)
+	}
+	enc := json.NewEncoder(f)
+	for _, record := range kept {
+		if err := enc.Encode(record); err != nil {
+			f.Close()
+			return fmt.Errorf("failed to encode dead-letter record: %w", err)
+		}
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to rewrite dead-letter file: %w", err)
+	}
+	return os.Rename(tmp, s.path)
+}
+
+// read loads every record from disk; callers must hold s.mu
+func (s *FileStore) read() ([]Record, error) {
+	f, err := os.Open(s.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open dead-letter file: %w", err)
+	}
+	defer f.Close()
+
+	var records []Record
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 64<<20) // payloads can be large
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var record Record
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return nil, fmt.Errorf("corrupt dead-letter record: %w", err)
+		}
+		records = append(records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read dead-letter file: %w", err)
+	}
+	return records, nil
+}
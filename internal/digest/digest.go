@@ -0,0 +1,204 @@
+package digest
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github_integration/internal/eventlog"
+	"github_integration/internal/github"
+	"github_integration/internal/notifier"
+	"github_integration/internal/utils"
+)
+
+// topContributors is how many of the most active GitHub users a summary lists
+const topContributors = 5
+
+// Summary aggregates the processed events of one period
+type Summary struct {
+	Since             time.Time
+	Events            int
+	Failed            int
+	PRsOpened         int
+	PRsMerged         int
+	PRsClosed         int
+	IssuesOpened      int
+	IssuesClosed      int
+	JiraIssuesCreated int
+	JiraIssuesClosed  int
+	// Contributors counts events per GitHub login
+	Contributors map[string]int
+}
+
+// Summarize aggregates event log records since a time. A delivery recorded twice (e.g. replayed)
+// counts once, and org-hook copies of events a repository hook also delivered for the same
+// repository are dropped, so activity seen by both hooks isn't double counted.
+func Summarize(records []eventlog.Record, since time.Time) Summary {
+	s := Summary{Since: since, Contributors: make(map[string]int)}
+
+	// Event types each repository's own hook delivered during the period
+	repoHooked := make(map[string]bool)
+	for _, record := range records {
+		if record.Endpoint == "repo" {
+			repoHooked[record.Repo+"/"+record.EventType] = true
+		}
+	}
+
+	seen := make(map[string]bool, len(records))
+	for _, record := range records {
+		if record.Timestamp.Before(since) || seen[record.ID] {
+			continue
+		}
+		seen[record.ID] = true
+		if record.Endpoint == "org" && repoHooked[record.Repo+"/"+record.EventType] {
+			continue
+		}
+		s.add(record)
+	}
+	return s
+}
+
+// add counts one record into the summary
+func (s *Summary) add(record eventlog.Record) {
+	s.Events++
+	if record.Outcome == eventlog.OutcomeFailed {
+		s.Failed++
+	}
+	if record.Actor != "" {
+		s.Contributors[record.Actor]++
+	}
+	if record.JiraKey != "" {
+		s.JiraIssuesCreated++
+	}
+
+	switch github.EventType(record.EventType) {
+	case github.EventPullRequest:
+		switch {
+		case record.Action == "opened":
+			s.PRsOpened++
+		case record.Action == "closed" && record.Merged:
+			s.PRsMerged++
+		case record.Action == "closed":
+			s.PRsClosed++
+		}
+	case github.EventIssues:
+		switch record.Action {
+		case "opened":
+			s.IssuesOpened++
+		case "closed":
+			s.IssuesClosed++
+		}
+	}
+}
+
+// TopContributors returns up to n logins with the most events, most active first
+func (s Summary) TopContributors(n int) []string {
+	logins := make([]string, 0, len(s.Contributors))
+	for login := range s.Contributors {
+		logins = append(logins, login)
+	}
+	sort.Slice(logins, func(i, j int) bool {
+		if s.Contributors[logins[i]] != s.Contributors[logins[j]] {
+			return s.Contributors[logins[i]] > s.Contributors[logins[j]]
+		}
+		return logins[i] < logins[j]
+	})
+	if len(logins) > n {
+		logins = logins[:n]
+	}
+	return logins
+}
+
+// Format renders the summary as a title and plain-text body
+func (s Summary) Format(until time.Time) (string, string) {
+	title := fmt.Sprintf("GitHub activity summary for %s", until.Format("2006-01-02"))
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Since %s:\n", s.Since.Format("2006-01-02 15:04"))
+	fmt.Fprintf(&b, "• PRs opened: %d, merged: %d, closed without merge: %d\n", s.PRsOpened, s.PRsMerged, s.PRsClosed)
+	fmt.Fprintf(&b, "• GitHub issues opened: %d, closed: %d\n", s.IssuesOpened, s.IssuesClosed)
+	fmt.Fprintf(&b, "• Jira issues created: %d, closed: %d\n", s.JiraIssuesCreated, s.JiraIssuesClosed)
+	fmt.Fprintf(&b, "• Events processed: %d (%d failed)\n", s.Events, s.Failed)
+	if top := s.TopContributors(topContributors); len(top) > 0 {
+		entries := make([]string, len(top))
+		for i, login := range top {
+			entries[i] = fmt.Sprintf("%s (%d)", login, s.Contributors[login])
+		}
+		fmt.Fprintf(&b, "• Top contributors: %s\n", strings.Join(entries, ", "))
+	}
+	return title, b.String()
+}
+
+// ClosedCounter counts the Jira issues closed since a time
+type ClosedCounter func(since time.Time) (int, error)
+
+// Scheduler posts the summary of the event log to its destinations once a day at a fixed local time
+type Scheduler struct {
+	records      eventlog.Reader
+	closed       ClosedCounter
+	hour, minute int
+	destinations []notifier.Notifier
+	logger       *utils.Logger
+}
+
+// NewScheduler posts at hour:minute local time to every destination; closed may be nil when
+// Jira isn't configured
+func NewScheduler(records eventlog.Reader, closed ClosedCounter, hour, minute int, destinations []notifier.Notifier, logger *utils.Logger) *Scheduler {
+	return &Scheduler{
+		records:      records,
+		closed:       closed,
+		hour:         hour,
+		minute:       minute,
+		destinations: destinations,
+		logger:       logger,
+	}
+}
+
+// Run posts a summary at every scheduled time until ctx is cancelled. Each summary covers the day
+// since the previous scheduled time, read back from the event log, so a restart loses nothing.
+func (s *Scheduler) Run(ctx context.Context) {
+	for {
+		timer := time.NewTimer(time.Until(s.next(time.Now())))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case now := <-timer.C:
+			s.post(now.AddDate(0, 0, -1), now)
+		}
+	}
+}
+
+// next returns the first scheduled time strictly after now
+func (s *Scheduler) next(now time.Time) time.Time {
+	at := time.Date(now.Year(), now.Month(), now.Day(), s.hour, s.minute, 0, 0, now.Location())
+	if !at.After(now) {
+		at = at.AddDate(0, 0, 1)
+	}
+	return at
+}
+
+// post sends the summary of the period from since to now to every destination
+func (s *Scheduler) post(since, now time.Time) {
+	records, err := s.records.ReadSince(since)
+	if err != nil {
+		s.logger.Error(fmt.Sprintf("Failed to read the event log for the daily summary: %v", err))
+		return
+	}
+	summary := Summarize(records, since)
+	if s.closed != nil {
+		if summary.JiraIssuesClosed, err = s.closed(since); err != nil {
+			s.logger.Error(fmt.Sprintf("WARNING: daily summary could not count closed Jira issues: %v", err))
+		}
+	}
+
+	title, body := summary.Format(now)
+	for _, destination := range s.destinations {
+		if err := destination.Notify(title, body); err != nil {
+			s.logger.Error(fmt.Sprintf("Failed to post daily summary: %v", err))
+		}
+	}
+	s.logger.Info(fmt.Sprintf("Posted daily summary to %d destinations", len(s.destinations)))
+}
@@ -0,0 +1,157 @@
+package eventlog
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// FileSink appends records to a local file, rotating it once it grows past maxBytes.
+// Rotated files are kept as path.1 (newest) to path.<backups>.
+type FileSink struct {
+	mu       sync.Mutex
+	path     string
+	maxBytes int64
+	backups  int
+	file     *os.File
+	size     int64
+}
+
+// NewFileSink opens (or creates) path for appending
+func NewFileSink(path string, maxBytes int64, backups int) (*FileSink, error) {
+	s := &FileSink{path: path, maxBytes: maxBytes, backups: backups}
+	if err := s.open(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *FileSink) open() error {
+	f, err := os.OpenFile(s.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open event log: %w", err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("failed to stat event log: %w", err)
+	}
+	s.file, s.size = f, info.Size()
+	return nil
+}
+
+// Write appends a record, rotating first if it would take the file past maxBytes
+func (s *FileSink) Write(record Record) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to encode event log record: %w", err)
+	}
+	line = append(line, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.file == nil {
+		return fmt.Errorf("event log %s is closed", s.path)
+	}
+	if s.maxBytes > 0 && s.size > 0 && s.size+int64(len(line)) > s.maxBytes {
+		if err := s.rotate(); err != nil {
+			return err
+		}
+	}
+	n, err := s.file.Write(line)
+	s.size += int64(n)
+	if err != nil {
+		return fmt.Errorf("failed to write event log record: %w", err)
+	}
+	return nil
+}
+
+// rotate shifts path.N-1 to path.N ... path to path.1 and starts a new file
+func (s *FileSink) rotate() error {
+	if err := s.file.Close(); err != nil {
+		return fmt.Errorf("failed to close event log for rotation: %w", err)
+	}
+	s.file = nil
+
+	if s.backups <= 0 {
+		if err := os.Remove(s.path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to truncate event log: %w", err)
+		}
+		return s.open()
+	}
+	for i := s.backups - 1; i >= 1; i-- {
+		// Missing older backups are expected until the log has rotated enough times
+		os.Rename(fmt.Sprintf("%s.%d", s.path, i), fmt.Sprintf("%s.%d", s.path, i+1))
+	}
+	if err := os.Rename(s.path, s.path+".1"); err != nil {
+		return fmt.Errorf("failed to rotate event log: %w", err)
+	}
+	return s.open()
+}
+
+// ReadSince reads the records logged at or after since from the rotated backups and the
+// current file, oldest first
+func (s *FileSink) ReadSince(since time.Time) ([]Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	paths := make([]string, 0, s.backups+1)
+	for i := s.backups; i >= 1; i-- {
+		paths = append(paths, fmt.Sprintf("%s.%d", s.path, i))
+	}
+	paths = append(paths, s.path)
+
+	var records []Record
+	for _, path := range paths {
+		f, err := os.Open(path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to open event log: %w", err)
+		}
+		read, err := readRecords(f, since)
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		records = append(records, read...)
+	}
+	return records, nil
+}
+
+// readRecords decodes JSON-lines records at or after since, skipping lines that don't parse
+// (e.g. one cut short by a crash mid-write)
+func readRecords(r io.Reader, since time.Time) ([]Record, error) {
+	var records []Record
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1<<20)
+	for scanner.Scan() {
+		var record Record
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			continue
+		}
+		if !record.Timestamp.Before(since) {
+			records = append(records, record)
+		}
+	}
+	return records, scanner.Err()
+}
+
+// Close closes the current file
+func (s *FileSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.file == nil {
+		return nil
+	}
+	err := s.file.Close()
+	s.file = nil
+	return err
+}
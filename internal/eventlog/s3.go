@@ -0,0 +1,410 @@
+package eventlog
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// S3Options configures an S3Sink
+type S3Options struct {
+	Bucket string
+	Region string
+	// Prefix is prepended to object keys, e.g. "github-jira/events"
+	Prefix string
+	// Endpoint overrides the AWS endpoint for S3-compatible stores and uses path-style URLs
+	Endpoint        string
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+	// Records are uploaded as one object once BatchSize are buffered or FlushInterval passes
+	BatchSize     int
+	FlushInterval time.Duration
+	// OnError reports uploads that failed in the background; the records are retried on the next flush
+	OnError func(error)
+}
+
+// maxBufferedRecords caps how many records are kept while uploads keep failing
+const maxBufferedRecords = 10000
+
+// Failed uploads are retried after a backoff doubling from minFlushBackoff up to maxFlushBackoff
+const (
+	minFlushBackoff = 5 * time.Second
+	maxFlushBackoff = 5 * time.Minute
+)
+
+// S3Sink batches records into JSON-lines objects under <prefix>/YYYY/MM/DD/ in a bucket.
+// Requests are signed with AWS Signature Version 4.
+type S3Sink struct {
+	opts   S3Options
+	client *http.Client
+
+	mu     sync.Mutex
+	buffer []Record
+	// uploading is the batch being uploaded, taken out of buffer so Write isn't blocked meanwhile
+	uploading []Record
+	seq       int
+	// failures counts consecutive failed uploads; none are attempted before retryAt
+	failures int
+	retryAt  time.Time
+
+	// flushing allows one upload at a time
+	flushing sync.Mutex
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewS3Sink starts an S3 sink flushing in the background
+func NewS3Sink(opts S3Options) (*S3Sink, error) {
+	if opts.Bucket == "" || opts.Region == "" {
+		return nil, fmt.Errorf("S3 event log needs a bucket and a region")
+	}
+	if opts.AccessKeyID == "" || opts.SecretAccessKey == "" {
+		return nil, fmt.Errorf("S3 event log needs AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY")
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = 500
+	}
+	if opts.FlushInterval <= 0 {
+		opts.FlushInterval = time.Minute
+	}
+	if opts.OnError == nil {
+		opts.OnError = func(error) {}
+	}
+
+	s := &S3Sink{
+		opts:   opts,
+		client: &http.Client{Timeout: 30 * time.Second},
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+	go s.flushLoop()
+	return s, nil
+}
+
+func (s *S3Sink) flushLoop() {
+	defer close(s.done)
+	ticker := time.NewTicker(s.opts.FlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.flush(false); err != nil {
+				s.opts.OnError(err)
+			}
+		case <-s.stop:
+			return
+		}
+	}
+}
+
+// Write buffers a record, uploading the batch once it is full
+func (s *S3Sink) Write(record Record) error {
+	s.mu.Lock()
+	s.buffer = append(s.buffer, record)
+	if len(s.buffer) > maxBufferedRecords {
+		s.buffer = s.buffer[len(s.buffer)-maxBufferedRecords:]
+	}
+	full := len(s.buffer) >= s.opts.BatchSize
+	s.mu.Unlock()
+
+	if full {
+		return s.flush(false)
+	}
+	return nil
+}
+
+// flush uploads everything buffered as one object, putting the records back if the upload
+// fails. Unless force is set, it does nothing while another upload runs or an earlier failure
+// is backing off.
+func (s *S3Sink) flush(force bool) error {
+	if force {
+		s.flushing.Lock()
+	} else if !s.flushing.TryLock() {
+		return nil
+	}
+	defer s.flushing.Unlock()
+
+	s.mu.Lock()
+	if len(s.buffer) == 0 || (!force && time.Now().Before(s.retryAt)) {
+		s.mu.Unlock()
+		return nil
+	}
+	batch := s.buffer
+	s.buffer, s.uploading = nil, batch
+	s.seq++
+	seq := s.seq
+	s.mu.Unlock()
+
+	now := time.Now().UTC()
+	key := s.keyPrefix() + fmt.Sprintf("%s/%s-%04d.jsonl", now.Format("2006/01/02"), now.Format("20060102T150405Z"), seq)
+	err := s.upload(key, batch, now)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.uploading = nil
+	if err == nil {
+		s.failures, s.retryAt = 0, time.Time{}
+		return nil
+	}
+
+	s.buffer = append(batch, s.buffer...)
+	if len(s.buffer) > maxBufferedRecords {
+		s.buffer = s.buffer[len(s.buffer)-maxBufferedRecords:]
+	}
+	backoff := maxFlushBackoff
+	if s.failures < 10 {
+		backoff = min(minFlushBackoff<<s.failures, maxFlushBackoff)
+	}
+	s.failures++
+	s.retryAt = time.Now().Add(backoff)
+	return fmt.Errorf("failed to upload %d event log records to s3://%s/%s (retrying in %s): %w", len(batch), s.opts.Bucket, key, backoff, err)
+}
+
+// upload writes records as one JSON-lines object
+func (s *S3Sink) upload(key string, records []Record, now time.Time) error {
+	var body bytes.Buffer
+	enc := json.NewEncoder(&body)
+	for _, record := range records {
+		if err := enc.Encode(record); err != nil {
+			return fmt.Errorf("failed to encode event log record: %w", err)
+		}
+	}
+	return s.put(key, body.Bytes(), now)
+}
+
+// put uploads one object with a SigV4-signed PUT request
+func (s *S3Sink) put(key string, body []byte, now time.Time) error {
+	_, err := s.send(http.MethodPut, key, nil, body, now)
+	return err
+}
+
+// get downloads one object
+func (s *S3Sink) get(key string) ([]byte, error) {
+	return s.send(http.MethodGet, key, nil, nil, time.Now().UTC())
+}
+
+// listBucketResult is the part of a ListObjectsV2 response the reader uses
+type listBucketResult struct {
+	Contents []struct {
+		Key string `xml:"Key"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+// list returns the keys of every object under prefix
+func (s *S3Sink) list(prefix string) ([]string, error) {
+	var keys []string
+	query := url.Values{"list-type": {"2"}, "prefix": {prefix}}
+	for {
+		body, err := s.send(http.MethodGet, "", query, nil, time.Now().UTC())
+		if err != nil {
+			return nil, err
+		}
+		var result listBucketResult
+		if err := xml.Unmarshal(body, &result); err != nil {
+			return nil, fmt.Errorf("invalid S3 listing: %w", err)
+		}
+		for _, object := range result.Contents {
+			keys = append(keys, object.Key)
+		}
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			return keys, nil
+		}
+		query.Set("continuation-token", result.NextContinuationToken)
+	}
+}
+
+// ReadSince reads the records logged at or after since: the uploaded objects of every day since
+// then, followed by those still buffered or being uploaded
+func (s *S3Sink) ReadSince(since time.Time) ([]Record, error) {
+	var records []Record
+	now := time.Now().UTC()
+	for day := since.UTC().Truncate(24 * time.Hour); !day.After(now); day = day.AddDate(0, 0, 1) {
+		keys, err := s.list(s.keyPrefix() + day.Format("2006/01/02") + "/")
+		if err != nil {
+			return nil, fmt.Errorf("failed to list event log objects: %w", err)
+		}
+		for _, key := range keys {
+			body, err := s.get(key)
+			if err != nil {
+				return nil, fmt.Errorf("failed to download s3://%s/%s: %w", s.opts.Bucket, key, err)
+			}
+			read, err := readRecords(bytes.NewReader(body), since)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read s3://%s/%s: %w", s.opts.Bucket, key, err)
+			}
+			records = append(records, read...)
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, record := range append(append([]Record(nil), s.uploading...), s.buffer...) {
+		if !record.Timestamp.Before(since) {
+			records = append(records, record)
+		}
+	}
+	return records, nil
+}
+
+// keyPrefix is the configured prefix as a key directory ("" or "prefix/")
+func (s *S3Sink) keyPrefix() string {
+	if prefix := strings.Trim(s.opts.Prefix, "/"); prefix != "" {
+		return prefix + "/"
+	}
+	return ""
+}
+
+// send performs one SigV4-signed request on key (the bucket itself when empty), returning the
+// response body of a successful request
+func (s *S3Sink) send(method, key string, query url.Values, body []byte, now time.Time) ([]byte, error) {
+	host := fmt.Sprintf("%s.s3.%s.amazonaws.com", s.opts.Bucket, s.opts.Region)
+	scheme := "https"
+	path := "/" + escapeKey(key)
+	if s.opts.Endpoint != "" {
+		endpoint := strings.TrimSuffix(s.opts.Endpoint, "/")
+		if i := strings.Index(endpoint, "://"); i >= 0 {
+			scheme, endpoint = endpoint[:i], endpoint[i+3:]
+		}
+		host = endpoint
+		path = "/" + s.opts.Bucket + path
+	}
+
+	rawQuery := canonicalQuery(query)
+	target := scheme + "://" + host + path
+	if rawQuery != "" {
+		target += "?" + rawQuery
+	}
+	req, err := http.NewRequest(method, target, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/x-ndjson")
+	}
+	s.sign(req, host, path, rawQuery, body, now)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("S3 returned %s: %s", resp.Status, strings.TrimSpace(string(detail)))
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// sign adds AWS Signature Version 4 headers; query must already be in canonical form
+func (s *S3Sink) sign(req *http.Request, host, path, query string, body []byte, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	payloadHash := sha256Hex(body)
+
+	headers := map[string]string{
+		"host":                 host,
+		"x-amz-content-sha256": payloadHash,
+		"x-amz-date":           amzDate,
+	}
+	if s.opts.SessionToken != "" {
+		headers["x-amz-security-token"] = s.opts.SessionToken
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+		if name != "host" {
+			req.Header.Set(name, headers[name])
+		}
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method, path, query, canonicalHeaders.String(), signedHeaders, payloadHash,
+	}, "\n")
+	scope := date + "/" + s.opts.Region + "/s3/aws4_request"
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256", amzDate, scope, sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.opts.SecretAccessKey), date)
+	key = hmacSHA256(key, s.opts.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.opts.AccessKeyID, scope, signedHeaders, signature))
+}
+
+// Close stops the background flush and uploads whatever is still buffered
+func (s *S3Sink) Close() error {
+	close(s.stop)
+	<-s.done
+	return s.flush(true)
+}
+
+// escapeKey URI-encodes each segment of an object key as SigV4 expects
+func escapeKey(key string) string {
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		segments[i] = uriEncode(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+// canonicalQuery renders query parameters sorted and encoded as SigV4 expects
+func canonicalQuery(query url.Values) string {
+	pairs := make([]string, 0, len(query))
+	for name, values := range query {
+		for _, value := range values {
+			pairs = append(pairs, uriEncode(name)+"="+uriEncode(value))
+		}
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, "&")
+}
+
+// uriEncode percent-encodes everything but SigV4's unreserved characters
+func uriEncode(s string) string {
+	var b strings.Builder
+	for _, c := range []byte(s) {
+		if ('A' <= c && c <= 'Z') || ('a' <= c && c <= 'z') || ('0' <= c && c <= '9') || strings.IndexByte("-._~", c) >= 0 {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
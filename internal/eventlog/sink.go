@@ -0,0 +1,41 @@
+package eventlog
+
+import (
+	"time"
+)
+
+// Record is one processed webhook delivery, written as a JSON line for downstream pipelines
+type Record struct {
+	ID        string    `json:"id"`
+	EventType string    `json:"event_type"`
+	Action    string    `json:"action,omitempty"`
+	Endpoint  string    `json:"endpoint,omitempty"`
+	Repo      string    `json:"repo,omitempty"`
+	Actor     string    `json:"actor,omitempty"`
+	PRNumber  int       `json:"pr_number,omitempty"`
+	Merged    bool      `json:"merged,omitempty"`
+	JiraKey   string    `json:"jira_key,omitempty"`
+	Outcome   string    `json:"outcome"`
+	Error     string    `json:"error,omitempty"`
+	Duration  float64   `json:"duration_ms"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// Outcomes recorded for a delivery
+const (
+	OutcomeOK     = "ok"
+	OutcomeFailed = "failed"
+)
+
+// EventSink receives event log records
+type EventSink interface {
+	Write(record Record) error
+	// Close flushes buffered records and releases the sink
+	Close() error
+}
+
+// Reader is implemented by sinks whose records can be read back (e.g. for the daily summary)
+type Reader interface {
+	// ReadSince returns the records with a Timestamp at or after since
+	ReadSince(since time.Time) ([]Record, error)
+}
@@ -0,0 +1,181 @@
+package events
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github_integration/internal/utils"
+)
+
+// asyncBuffer is how many events an async subscriber can fall behind before Publish blocks
+const asyncBuffer = 100
+
+// ProcessedEvent describes one webhook delivery after the handler finished with it
+type ProcessedEvent struct {
+	ID        string
+	EventType string
+	Action    string
+	Endpoint  string
+	Repo      string
+	PRNumber  int
+	// Merged is set for a pull_request "closed" delivery whose PR was merged
+	Merged bool
+	// Actor is the GitHub login that triggered the event
+	Actor string
+	// Issue is the Jira issue created for the delivery, if any
+	Issue     string
+	Err       error
+	Duration  time.Duration
+	Timestamp time.Time
+}
+
+// Subscriber consumes processed events
+type Subscriber func(event ProcessedEvent)
+
+// SubscribeOption configures a subscription
+type SubscribeOption func(*subscription)
+
+// Async delivers events to the subscriber on its own goroutine instead of inside Publish
+func Async() SubscribeOption {
+	return func(s *subscription) {
+		s.async = true
+	}
+}
+
+type subscription struct {
+	name   string
+	fn     Subscriber
+	async  bool
+	events chan ProcessedEvent
+}
+
+// Bus fans processed events out to independent subscribers, keeping recent events for replay
+type Bus struct {
+	mu          sync.RWMutex
+	subs        []*subscription
+	history     []ProcessedEvent
+	historySize int
+	wg          sync.WaitGroup
+	closed      bool
+
+	// onPanic reports a subscriber that panicked; the other subscribers still run
+	onPanic func(name string, recovered interface{})
+}
+
+// NewBus creates a bus remembering the last historySize events; subscriber panics are logged
+// until OnPanic replaces the callback
+func NewBus(historySize int) *Bus {
+	logger := utils.NewLogger()
+	return &Bus{
+		historySize: historySize,
+		onPanic: func(name string, recovered interface{}) {
+			logger.Error(fmt.Sprintf("Event subscriber %s panicked: %v", name, recovered))
+		},
+	}
+}
+
+// OnPanic sets the callback used when a subscriber panics
+func (b *Bus) OnPanic(fn func(name string, recovered interface{})) {
+	b.onPanic = fn
+}
+
+// Subscribe registers fn under name; subscribers are expected to register at startup
+func (b *Bus) Subscribe(name string, fn Subscriber, opts ...SubscribeOption) {
+	sub := &subscription{name: name, fn: fn}
+	for _, opt := range opts {
+		opt(sub)
+	}
+
+	if sub.async {
+		sub.events = make(chan ProcessedEvent, asyncBuffer)
+		b.wg.Add(1)
+		go func() {
+			defer b.wg.Done()
+			for event := range sub.events {
+				b.deliver(sub, event)
+			}
+		}()
+	}
+
+	b.mu.Lock()
+	b.subs = append(b.subs, sub)
+	b.mu.Unlock()
+}
+
+// Publish records event and hands it to every subscriber
+func (b *Bus) Publish(event ProcessedEvent) {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return
+	}
+	if b.historySize > 0 {
+		b.history = append(b.history, event)
+		if len(b.history) > b.historySize {
+			b.history = b.history[len(b.history)-b.historySize:]
+		}
+	}
+	subs := b.subs
+	b.mu.Unlock()
+
+	for _, sub := range subs {
+		if !sub.async {
+			b.deliver(sub, event)
+		}
+	}
+
+	// Close closes the async channels under the write lock, so sending under the read lock
+	// after checking closed can't hit a closed channel
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if b.closed {
+		return
+	}
+	for _, sub := range subs {
+		if sub.async {
+			sub.events <- event
+		}
+	}
+}
+
+// History returns the remembered events, oldest first
+func (b *Bus) History() []ProcessedEvent {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return append([]ProcessedEvent(nil), b.history...)
+}
+
+// Replay delivers the remembered events to fn, e.g. to warm up a subscriber added late
+func (b *Bus) Replay(fn Subscriber) {
+	for _, event := range b.History() {
+		b.deliver(&subscription{name: "replay", fn: fn}, event)
+	}
+}
+
+// Close stops accepting events and waits for async subscribers to drain
+func (b *Bus) Close() {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return
+	}
+	b.closed = true
+	for _, sub := range b.subs {
+		if sub.async {
+			close(sub.events)
+		}
+	}
+	b.mu.Unlock()
+	b.wg.Wait()
+}
+
+// deliver calls one subscriber, containing any panic so it can't break the others
+func (b *Bus) deliver(sub *subscription, event ProcessedEvent) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			b.onPanic(sub.name, recovered)
+		}
+	}()
+	sub.fn(event)
+}
@@ -0,0 +1,60 @@
+package events
+
+import (
+	"fmt"
+
+	"github_integration/internal/eventlog"
+	"github_integration/internal/metrics"
+	"github_integration/internal/notifier"
+)
+
+var (
+	eventsProcessed = metrics.NewCounter("webhook_events_processed_total", "Webhook deliveries processed")
+	eventsFailed    = metrics.NewCounter("webhook_events_failed_total", "Webhook deliveries whose processing reported an error")
+)
+
+// RecordMetrics counts processed and failed deliveries
+func RecordMetrics(event ProcessedEvent) {
+	eventsProcessed.Inc()
+	if event.Err != nil {
+		eventsFailed.Inc()
+	}
+}
+
+// WriteEventLog writes each event to sink as an event log record, reporting write failures to onError
+func WriteEventLog(sink eventlog.EventSink, onError func(error)) Subscriber {
+	return func(event ProcessedEvent) {
+		record := eventlog.Record{
+			ID:        event.ID,
+			EventType: event.EventType,
+			Action:    event.Action,
+			Endpoint:  event.Endpoint,
+			Repo:      event.Repo,
+			Actor:     event.Actor,
+			PRNumber:  event.PRNumber,
+			Merged:    event.Merged,
+			JiraKey:   event.Issue,
+			Outcome:   eventlog.OutcomeOK,
+			Duration:  float64(event.Duration.Microseconds()) / 1000,
+			Timestamp: event.Timestamp,
+		}
+		if event.Err != nil {
+			record.Outcome = eventlog.OutcomeFailed
+			record.Error = event.Err.Error()
+		}
+		if err := sink.Write(record); err != nil {
+			onError(err)
+		}
+	}
+}
+
+// AlertOnFailure sends failed deliveries to n
+func AlertOnFailure(n notifier.Notifier) Subscriber {
+	return func(event ProcessedEvent) {
+		if event.Err == nil {
+			return
+		}
+		n.Notify(fmt.Sprintf("Failed to process %s event", event.EventType),
+			fmt.Sprintf("Delivery %s for %s: %v", event.ID, event.Repo, event.Err))
+	}
+}
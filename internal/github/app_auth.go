@@ -0,0 +1,87 @@
+package github
+
+import (
+	"context"
+	"crypto/rsa"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/go-github/v56/github"
+	"golang.org/x/oauth2"
+
+	"github_integration/internal/retry"
+)
+
+// AppCredentials identifies a GitHub App installation used instead of a personal token
+type AppCredentials struct {
+	AppID          int64
+	InstallationID int64
+	PrivateKeyPath string
+}
+
+// installationTokenSource exchanges a signed app JWT for short-lived installation tokens
+type installationTokenSource struct {
+	ctx            context.Context
+	appID          int64
+	installationID int64
+	key            *rsa.PrivateKey
+}
+
+// NewAppClient creates a GitHub API client authenticated as a GitHub App installation
+func NewAppClient(creds AppCredentials, org string) (*Client, error) {
+	pem, err := os.ReadFile(creds.PrivateKeyPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read GitHub App private key: %w", err)
+	}
+	key, err := jwt.ParseRSAPrivateKeyFromPEM(pem)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse GitHub App private key: %w", err)
+	}
+
+	ctx := context.Background()
+	ts := oauth2.ReuseTokenSource(nil, &installationTokenSource{
+		ctx:            ctx,
+		appID:          creds.AppID,
+		installationID: creds.InstallationID,
+		key:            key,
+	})
+
+	return &Client{
+		client:     github.NewClient(oauth2.NewClient(ctx, ts)),
+		org:        org,
+		ctx:        ctx,
+		retry:      retry.DefaultPolicy,
+		owners:     &repoOwners{owners: make(map[string]string)},
+		teams:      &teamRepoCache{entries: make(map[string]teamRepoEntry)},
+		rateLimits: &rateLimitCache{},
+	}, nil
+}
+
+// Token mints a new installation token; oauth2.ReuseTokenSource caches it until expiry
+func (s *installationTokenSource) Token() (*oauth2.Token, error) {
+	now := time.Now()
+	claims := jwt.RegisteredClaims{
+		Issuer:    strconv.FormatInt(s.appID, 10),
+		IssuedAt:  jwt.NewNumericDate(now.Add(-time.Minute)), // allow for clock drift
+		ExpiresAt: jwt.NewNumericDate(now.Add(9 * time.Minute)),
+	}
+	appJWT, err := jwt.NewWithClaims(jwt.SigningMethodRS256, claims).SignedString(s.key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign GitHub App JWT: %w", err)
+	}
+
+	appClient := github.NewClient(nil).WithAuthToken(appJWT)
+	token, _, err := appClient.Apps.CreateInstallationToken(s.ctx, s.installationID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create installation token: %w", err)
+	}
+
+	return &oauth2.Token{
+		AccessToken: token.GetToken(),
+		TokenType:   "token",
+		Expiry:      token.GetExpiresAt().Time,
+	}, nil
+}
@@ -0,0 +1,76 @@
+package github
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v56/github"
+
+	"github_integration/internal/metrics"
+)
+
+var (
+	repoCacheHits   = metrics.NewCounter("github_repo_cache_hits_total", "Repository detail lookups served from cache")
+	repoCacheMisses = metrics.NewCounter("github_repo_cache_misses_total", "Repository detail lookups that called the GitHub API")
+)
+
+// repoCache is a TTL cache of repository details keyed by repo name
+type repoCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]repoCacheEntry
+}
+
+type repoCacheEntry struct {
+	repo      *github.Repository
+	expiresAt time.Time
+}
+
+func newRepoCache(ttl time.Duration) *repoCache {
+	return &repoCache{ttl: ttl, entries: make(map[string]repoCacheEntry)}
+}
+
+// get returns a cached repository if present and not expired
+func (rc *repoCache) get(name string) (*github.Repository, bool) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	entry, ok := rc.entries[name]
+	if !ok || time.Now().After(entry.expiresAt) {
+		delete(rc.entries, name)
+		repoCacheMisses.Inc()
+		return nil, false
+	}
+	repoCacheHits.Inc()
+	return entry.repo, true
+}
+
+// set stores repository details for the cache TTL
+func (rc *repoCache) set(name string, repo *github.Repository) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.entries[name] = repoCacheEntry{repo: repo, expiresAt: time.Now().Add(rc.ttl)}
+}
+
+// invalidate drops any cached entry for the repository
+func (rc *repoCache) invalidate(name string) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	delete(rc.entries, name)
+}
+
+// EnableRepoCache caches GetRepositoryDetails results for ttl (zero disables caching)
+func (c *Client) EnableRepoCache(ttl time.Duration) {
+	if ttl <= 0 {
+		c.repos = nil
+		return
+	}
+	c.repos = newRepoCache(ttl)
+}
+
+// InvalidateRepository drops cached details for a repository (e.g. after it was edited)
+func (c *Client) InvalidateRepository(repoName string) {
+	if c.repos != nil {
+		c.repos.invalidate(repoName)
+	}
+}
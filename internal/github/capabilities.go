@@ -0,0 +1,101 @@
+package github
+
+import (
+	"strings"
+
+	"github.com/google/go-github/v56/github"
+)
+
+// CapabilityCheck is the outcome of probing one operation the integration relies on
+type CapabilityCheck struct {
+	Name string
+	Err  error
+}
+
+// OK reports whether the token can perform the operation
+func (c CapabilityCheck) OK() bool {
+	return c.Err == nil
+}
+
+// CapabilityReport describes what the configured token is allowed to do
+type CapabilityReport struct {
+	// Scopes lists a classic PAT's OAuth scopes; it is nil for fine-grained PATs and app tokens,
+	// which don't report scopes
+	Scopes []string
+	// Repo is the repository the repository-level checks ran against
+	Repo   string
+	Checks []CapabilityCheck
+}
+
+// Missing returns the names of the capabilities the token lacks
+func (r *CapabilityReport) Missing() []string {
+	var missing []string
+	for _, check := range r.Checks {
+		if !check.OK() {
+			missing = append(missing, check.Name)
+		}
+	}
+	return missing
+}
+
+// CheckCapabilities reads the token's scopes and attempts the read-only operations behind each
+// feature (hook management, PR files, commits) against one of the org's repositories
+func (c *Client) CheckCapabilities() (*CapabilityReport, error) {
+	ctx, span := c.startSpan("CheckCapabilities")
+	defer span.End()
+
+	report := &CapabilityReport{}
+
+	// GitHub App installation tokens can't read /user, so a failure here doesn't end the report
+	_, resp, err := call(c, ctx, func() (*github.User, *github.Response, error) {
+		return c.client.Users.Get(ctx, "")
+	})
+	report.Checks = append(report.Checks, CapabilityCheck{Name: "read authenticated user", Err: err})
+	if err != nil {
+		span.RecordError(err)
+	} else if header, ok := resp.Header["X-Oauth-Scopes"]; ok && len(header) > 0 {
+		report.Scopes = []string{}
+		for _, scope := range strings.Split(header[0], ",") {
+			if scope = strings.TrimSpace(scope); scope != "" {
+				report.Scopes = append(report.Scopes, scope)
+			}
+		}
+	}
+
+	_, _, err = call(c, ctx, func() ([]*github.Hook, *github.Response, error) {
+		return c.client.Organizations.ListHooks(ctx, c.org, &github.ListOptions{PerPage: 1})
+	})
+	report.Checks = append(report.Checks, CapabilityCheck{Name: "manage org webhooks", Err: err})
+
+	repos, _, err := call(c, ctx, func() ([]*github.Repository, *github.Response, error) {
+		return c.client.Repositories.ListByOrg(ctx, c.org, &github.RepositoryListByOrgOptions{ListOptions: github.ListOptions{PerPage: 1}})
+	})
+	report.Checks = append(report.Checks, CapabilityCheck{Name: "list org repositories", Err: err})
+	if err != nil || len(repos) == 0 {
+		return report, nil
+	}
+
+	repo := repos[0].GetName()
+	report.Repo = repo
+
+	_, _, err = call(c, ctx, func() ([]*github.Hook, *github.Response, error) {
+		return c.client.Repositories.ListHooks(ctx, c.ownerOf(repo), repo, &github.ListOptions{PerPage: 1})
+	})
+	report.Checks = append(report.Checks, CapabilityCheck{Name: "create repository webhooks", Err: err})
+
+	_, _, err = call(c, ctx, func() ([]*github.PullRequest, *github.Response, error) {
+		return c.client.PullRequests.List(ctx, c.ownerOf(repo), repo, &github.PullRequestListOptions{State: "all", ListOptions: github.ListOptions{PerPage: 1}})
+	})
+	report.Checks = append(report.Checks, CapabilityCheck{Name: "read pull requests and files", Err: err})
+
+	_, _, err = call(c, ctx, func() ([]*github.RepositoryCommit, *github.Response, error) {
+		return c.client.Repositories.ListCommits(ctx, c.ownerOf(repo), repo, &github.CommitsListOptions{ListOptions: github.ListOptions{PerPage: 1}})
+	})
+	// An empty repository answers 409 but still proves read access
+	if IsConflict(err) {
+		err = nil
+	}
+	report.Checks = append(report.Checks, CapabilityCheck{Name: "read commits", Err: err})
+
+	return report, nil
+}
@@ -2,11 +2,19 @@ package github
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
 	"strings"
+	"time"
 
 	"github.com/google/go-github/v56/github"
+	"go.opentelemetry.io/otel/attribute"
 	"golang.org/x/oauth2"
+
+	"github_integration/internal/retry"
+	"github_integration/internal/utils"
 )
 
 // Client wraps GitHub API client with organization context
@@ -14,6 +22,18 @@ type Client struct {
 	client *github.Client
 	org    string
 	ctx    context.Context
+	repos  *repoCache
+	retry  retry.Policy
+	// diffIgnore hides matching files (lockfiles, vendored code...) from diffs and file lists
+	diffIgnore *utils.GlobSet
+	// prDetailsGraphQL fetches PR details in one GraphQL query instead of three REST calls
+	prDetailsGraphQL bool
+	// diagnoseNotFound checks repository access when a PR lookup 404s
+	diagnoseNotFound bool
+	// owners overrides the org for repositories transferred to another account
+	owners     *repoOwners
+	teams      *teamRepoCache
+	rateLimits *rateLimitCache
 }
 
 // NewClient creates a new GitHub API client
@@ -30,14 +50,121 @@ func NewClient(token, org string) *Client {
 	client := github.NewClient(tc)
 
 	return &Client{
-		client: client,
-		org:    org,
-		ctx:    ctx,
+		client:     client,
+		org:        org,
+		ctx:        ctx,
+		retry:      retry.DefaultPolicy,
+		owners:     &repoOwners{owners: make(map[string]string)},
+		teams:      &teamRepoCache{entries: make(map[string]teamRepoEntry)},
+		rateLimits: &rateLimitCache{},
+	}
+}
+
+// SetUserAgent replaces go-github's default User-Agent on outbound requests
+func (c *Client) SetUserAgent(userAgent string) {
+	if userAgent != "" {
+		c.client.UserAgent = userAgent
+	}
+}
+
+// SetRetryPolicy overrides how transient API failures (5xx, secondary rate limits) are retried
+func (c *Client) SetRetryPolicy(policy retry.Policy) {
+	c.retry = policy
+}
+
+// SetDiffIgnoreGlobs hides files matching any of patterns from diffs and changed-file lists
+func (c *Client) SetDiffIgnoreGlobs(patterns []string) {
+	c.diffIgnore = utils.NewGlobSet(patterns)
+}
+
+// UsePRDetailsGraphQL switches GetPullRequestDetails to the single-query GraphQL path
+func (c *Client) UsePRDetailsGraphQL(enabled bool) {
+	c.prDetailsGraphQL = enabled
+}
+
+// DiagnoseNotFound makes PR lookups that 404 check whether the repository itself is visible,
+// returning ErrNoRepoAccess when it isn't
+func (c *Client) DiagnoseNotFound(enabled bool) {
+	c.diagnoseNotFound = enabled
+}
+
+// filterFiles drops files matched by the ignore globs, returning the kept files and how many were dropped
+func (c *Client) filterFiles(files []*github.CommitFile) ([]*github.CommitFile, int) {
+	if c.diffIgnore.Empty() {
+		return files, 0
+	}
+	kept := make([]*github.CommitFile, 0, len(files))
+	for _, file := range files {
+		if !c.diffIgnore.Match(file.GetFilename()) {
+			kept = append(kept, file)
+		}
 	}
+	return kept, len(files) - len(kept)
+}
+
+// sumChanges totals the added and deleted lines of files
+func sumChanges(files []*github.CommitFile) (additions, deletions int) {
+	for _, file := range files {
+		additions += file.GetAdditions()
+		deletions += file.GetDeletions()
+	}
+	return additions, deletions
+}
+
+// ChangeStats counts files and their added and deleted lines, leaving out files hidden by
+// DIFF_IGNORE_GLOBS so the totals agree with the reported diffs
+func (c *Client) ChangeStats(files []*github.CommitFile) (changed, additions, deletions int) {
+	kept, _ := c.filterFiles(files)
+	additions, deletions = sumChanges(kept)
+	return len(kept), additions, deletions
 }
 
-// CreateRepoWebhook automatically adds webhook to a specific repository
-func (c *Client) CreateRepoWebhook(repoName, webhookURL string) error {
+// call runs one API request under the retry policy, classifying errors so
+// secondary rate limits wait for GitHub's advised Retry-After delay
+func call[T any](c *Client, ctx context.Context, op func() (T, *github.Response, error)) (T, *github.Response, error) {
+	return callWith(c, ctx, false, op)
+}
+
+// callRepeatable is call for a write that is safe to repeat (e.g. a duplicate is detected and
+// ignored), so 5xx and network errors are retried as for reads
+func callRepeatable[T any](c *Client, ctx context.Context, op func() (T, *github.Response, error)) (T, *github.Response, error) {
+	return callWith(c, ctx, true, op)
+}
+
+func callWith[T any](c *Client, ctx context.Context, repeatable bool, op func() (T, *github.Response, error)) (T, *github.Response, error) {
+	var (
+		result T
+		resp   *github.Response
+	)
+	err := c.retry.Do(ctx, func() error {
+		var err error
+		result, resp, err = op()
+		return classifyError(err, repeatable)
+	})
+	return result, resp, err
+}
+
+// DefaultWebhookEvents are the events a repository webhook subscribes to unless configured otherwise
+var DefaultWebhookEvents = []string{
+	string(EventPush),
+	string(EventPullRequest),
+	string(EventIssues),
+	string(EventRepository),
+	string(EventRelease),
+	string(EventCommitComment),
+	string(EventIssueComment),
+	string(EventCheckRun),
+	string(EventCheckSuite),
+	string(EventDeploymentStatus),
+}
+
+// CreateRepoWebhook automatically adds webhook to a specific repository. A non-empty secret
+// makes GitHub sign deliveries so their X-Hub-Signature-256 can be verified. Empty events
+// subscribes to DefaultWebhookEvents.
+func (c *Client) CreateRepoWebhook(repoName, webhookURL, secret string, events []string) error {
+	ctx, span := c.startSpan("CreateRepoWebhook", attribute.String("github.repo", repoName))
+	defer span.End()
+
 	// Webhook configuration
 	hook := &github.Hook{
 		Name: github.String("web"),
@@ -46,21 +173,26 @@ func (c *Client) CreateRepoWebhook(repoName, webhookURL string) error {
 			"content_type": "json",
 			"insecure_ssl": "0", // Always verify SSL
 		},
-		Events: []string{
-			"push",
-			"pull_request",
-			"issues",
-			"repository",
-			"release",
-			"commit_comment",
-		},
+		Events: DefaultWebhookEvents,
 		Active: github.Bool(true),
 	}
+	if len(events) > 0 {
+		hook.Events = events
+	}
+	if secret != "" {
+		hook.Config["secret"] = secret
+	}
 
-	// Create webhook via GitHub API
-	_, _, err := c.client.Repositories.CreateHook(c.ctx, c.org, repoName, hook)
+	// Create webhook via GitHub API; a retry after a create that did land fails with
+	// "hook already exists", reported as ErrHookExists, so repeating it is safe
+	_, _, err := callRepeatable(c, ctx, func() (*github.Hook, *github.Response, error) {
+		return c.client.Repositories.CreateHook(ctx, c.ownerOf(repoName), repoName, hook)
+	})
+	if isHookExists(err) {
+		return fmt.Errorf("repo %s: %w", repoName, ErrHookExists)
+	}
 	if err != nil {
-		return fmt.Errorf("failed to create webhook for repo %s: %w", repoName, err)
+		return recordError(span, fmt.Errorf("failed to create webhook for repo %s: %w", repoName, err))
 	}
 
 	return nil
@@ -68,81 +200,472 @@ func (c *Client) CreateRepoWebhook(repoName, webhookURL string) error {
 
 // GetCommitDetails gets detailed information about a specific commit
 func (c *Client) GetCommitDetails(repoName, commitSHA string) (*github.RepositoryCommit, error) {
-	commit, _, err := c.client.Repositories.GetCommit(c.ctx, c.org, repoName, commitSHA, nil)
+	ctx, span := c.startSpan("GetCommitDetails", attribute.String("github.repo", repoName), attribute.String("github.sha", commitSHA))
+	defer span.End()
+
+	commit, _, err := call(c, ctx, func() (*github.RepositoryCommit, *github.Response, error) {
+		return c.client.Repositories.GetCommit(ctx, c.ownerOf(repoName), repoName, commitSHA, nil)
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get commit details: %w", err)
+		return nil, recordError(span, fmt.Errorf("failed to get commit details: %w", err))
 	}
 	return commit, nil
 }
 
-// GetFileDiff gets the diff content for files in a commit
+// GetFileDiff gets the formatted file diffs of a commit as one string (see WriteFileDiff)
 func (c *Client) GetFileDiff(repoName, commitSHA string) (string, error) {
+	var diffBuilder strings.Builder
+	if err := c.WriteFileDiff(&diffBuilder, repoName, commitSHA); err != nil {
+		return "", err
+	}
+	return diffBuilder.String(), nil
+}
+
+// WriteFileDiff writes a commit's formatted file diffs to w file by file, so large commits can be
+// streamed to a log, a file or a truncating writer without building the whole report in memory
+func (c *Client) WriteFileDiff(w io.Writer, repoName, commitSHA string) error {
+	ctx, span := c.startSpan("WriteFileDiff", attribute.String("github.repo", repoName), attribute.String("github.sha", commitSHA))
+	defer span.End()
+
 	// Get commit with diff data
-	commit, _, err := c.client.Repositories.GetCommit(c.ctx, c.org, repoName, commitSHA, nil)
+	commit, _, err := call(c, ctx, func() (*github.RepositoryCommit, *github.Response, error) {
+		return c.client.Repositories.GetCommit(ctx, c.ownerOf(repoName), repoName, commitSHA, nil)
+	})
 	if err != nil {
-		return "", fmt.Errorf("failed to get commit diff: %w", err)
+		return recordError(span, fmt.Errorf("failed to get commit diff: %w", err))
 	}
 
+	files, ignored := c.filterFiles(commit.Files)
+
 	// Build comprehensive diff information
-	var diffBuilder strings.Builder
+	ew := &errWriter{w: w}
+	fmt.Fprintf(ew, "=== COMMIT DIFF: %s ===\n", commitSHA[:8])
+	fmt.Fprintf(ew, "Total files changed: %d\n", len(files))
+	if ignored > 0 {
+		fmt.Fprintf(ew, "Ignored files: %d (matched DIFF_IGNORE_GLOBS)\n", ignored)
+	}
+	additions, deletions := sumChanges(files)
+	fmt.Fprintf(ew, "Additions: +%d, Deletions: -%d\n", additions, deletions)
+	fmt.Fprint(ew, "="+strings.Repeat("=", 50)+"\n\n")
 
-	diffBuilder.WriteString(fmt.Sprintf("=== COMMIT DIFF: %s ===\n", commitSHA[:8]))
-	diffBuilder.WriteString(fmt.Sprintf("Total files changed: %d\n", len(commit.Files)))
-	diffBuilder.WriteString(fmt.Sprintf("Additions: +%d, Deletions: -%d\n",
-		commit.Stats.GetAdditions(), commit.Stats.GetDeletions()))
-	diffBuilder.WriteString("=" + strings.Repeat("=", 50) + "\n\n")
+	writeFileDiffs(ew, files)
+
+	if ew.err != nil {
+		return recordError(span, fmt.Errorf("failed to write commit diff: %w", ew.err))
+	}
+	return nil
+}
+
+// errWriter remembers the first write error so a report can be written without checking every call;
+// writes after a failure are dropped
+type errWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (ew *errWriter) Write(p []byte) (int, error) {
+	if ew.err != nil {
+		return 0, ew.err
+	}
+	n, err := ew.w.Write(p)
+	ew.err = err
+	return n, err
+}
 
-	// Process each changed file
-	for i, file := range commit.Files {
-		diffBuilder.WriteString(fmt.Sprintf("FILE %d: %s\n", i+1, file.GetFilename()))
-		diffBuilder.WriteString(fmt.Sprintf("Status: %s\n", file.GetStatus()))
-		diffBuilder.WriteString(fmt.Sprintf("Changes: +%d/-%d lines\n",
-			file.GetAdditions(), file.GetDeletions()))
+// writeFileDiffs writes each changed file's stats and patch to a diff report
+func writeFileDiffs(w io.Writer, files []*github.CommitFile) {
+	for i, file := range files {
+		fmt.Fprintf(w, "FILE %d: %s\n", i+1, file.GetFilename())
+		fmt.Fprintf(w, "Status: %s\n", file.GetStatus())
+		fmt.Fprintf(w, "Changes: +%d/-%d lines\n",
+			file.GetAdditions(), file.GetDeletions())
 
 		// Add patch content if available (file diff)
 		if file.Patch != nil {
-			diffBuilder.WriteString("DIFF:\n")
-			diffBuilder.WriteString(*file.Patch)
-			diffBuilder.WriteString("\n")
+			io.WriteString(w, "DIFF:\n")
+			io.WriteString(w, *file.Patch)
+			io.WriteString(w, "\n")
 		}
-		diffBuilder.WriteString(strings.Repeat("-", 60) + "\n")
+		io.WriteString(w, strings.Repeat("-", 60)+"\n")
 	}
-
-	return diffBuilder.String(), nil
 }
 
 // GetPullRequestDetails gets detailed PR information including file changes
 func (c *Client) GetPullRequestDetails(repoName string, prNumber int) (*PRDetails, error) {
+	if c.prDetailsGraphQL {
+		return c.GetPullRequestDetailsGraphQL(repoName, prNumber)
+	}
+
+	ctx, span := c.startSpan("GetPullRequestDetails", attribute.String("github.repo", repoName), attribute.Int("github.pr", prNumber))
+	defer span.End()
+
 	// Get PR basic info
-	pr, _, err := c.client.PullRequests.Get(c.ctx, c.org, repoName, prNumber)
+	pr, _, err := call(c, ctx, func() (*github.PullRequest, *github.Response, error) {
+		return c.client.PullRequests.Get(ctx, c.ownerOf(repoName), repoName, prNumber)
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get PR details: %w", err)
+		return nil, recordError(span, fmt.Errorf("failed to get PR details: %w", c.explainNotFound(ctx, repoName, err)))
 	}
 
 	// Get PR files
-	prFiles, _, err := c.client.PullRequests.ListFiles(c.ctx, c.org, repoName, prNumber, nil)
+	prFiles, _, err := call(c, ctx, func() ([]*github.CommitFile, *github.Response, error) {
+		return c.client.PullRequests.ListFiles(ctx, c.ownerOf(repoName), repoName, prNumber, nil)
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get PR files: %w", err)
+		return nil, recordError(span, fmt.Errorf("failed to get PR files: %w", err))
 	}
 
 	// Get PR reviews
-	reviews, _, err := c.client.PullRequests.ListReviews(c.ctx, c.org, repoName, prNumber, nil)
+	reviews, _, err := call(c, ctx, func() ([]*github.PullRequestReview, *github.Response, error) {
+		return c.client.PullRequests.ListReviews(ctx, c.ownerOf(repoName), repoName, prNumber, nil)
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get PR reviews: %w", err)
+		return nil, recordError(span, fmt.Errorf("failed to get PR reviews: %w", err))
 	}
 
+	files, ignored := c.filterFiles(prFiles)
+
 	return &PRDetails{
-		PullRequest: pr,
-		Files:       prFiles,
-		Reviews:     reviews,
+		PullRequest:  pr,
+		Files:        files,
+		IgnoredFiles: ignored,
+		Reviews:      reviews,
 	}, nil
 }
 
-// GetRepositoryDetails gets comprehensive repository information
+// GetRepositoryDetails gets comprehensive repository information (cached when enabled)
 func (c *Client) GetRepositoryDetails(repoName string) (*github.Repository, error) {
-	repo, _, err := c.client.Repositories.Get(c.ctx, c.org, repoName)
+	if c.repos != nil {
+		if repo, ok := c.repos.get(repoName); ok {
+			return repo, nil
+		}
+	}
+
+	ctx, span := c.startSpan("GetRepositoryDetails", attribute.String("github.repo", repoName))
+	defer span.End()
+
+	repo, _, err := call(c, ctx, func() (*github.Repository, *github.Response, error) {
+		return c.client.Repositories.Get(ctx, c.ownerOf(repoName), repoName)
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get repository details: %w", err)
+		return nil, recordError(span, fmt.Errorf("failed to get repository details: %w", err))
+	}
+
+	if c.repos != nil {
+		c.repos.set(repoName, repo)
 	}
 	return repo, nil
 }
+
+// RepoTopics returns a repository's topics (served from the repository cache when enabled)
+func (c *Client) RepoTopics(repoName string) ([]string, error) {
+	repo, err := c.GetRepositoryDetails(repoName)
+	if err != nil {
+		return nil, err
+	}
+	return repo.Topics, nil
+}
+
+// GetPRFirstCommitMessage returns the message of a PR's first commit, or "" for a PR without commits
+func (c *Client) GetPRFirstCommitMessage(repoName string, prNumber int) (string, error) {
+	ctx, span := c.startSpan("GetPRFirstCommitMessage", attribute.String("github.repo", repoName), attribute.Int("github.pr", prNumber))
+	defer span.End()
+
+	commits, _, err := call(c, ctx, func() ([]*github.RepositoryCommit, *github.Response, error) {
+		return c.client.PullRequests.ListCommits(ctx, c.ownerOf(repoName), repoName, prNumber, &github.ListOptions{PerPage: 1})
+	})
+	if err != nil {
+		return "", recordError(span, fmt.Errorf("failed to list commits of PR #%d: %w", prNumber, err))
+	}
+	if len(commits) == 0 {
+		return "", nil
+	}
+	return commits[0].GetCommit().GetMessage(), nil
+}
+
+// GetUserEmail returns a user's public profile email, or "" when they don't publish one
+func (c *Client) GetUserEmail(login string) (string, error) {
+	ctx, span := c.startSpan("GetUserEmail", attribute.String("github.user", login))
+	defer span.End()
+
+	user, _, err := call(c, ctx, func() (*github.User, *github.Response, error) {
+		return c.client.Users.Get(ctx, login)
+	})
+	if err != nil {
+		return "", recordError(span, fmt.Errorf("failed to get user %s: %w", login, err))
+	}
+	return user.GetEmail(), nil
+}
+
+// ValidateToken checks that the configured token is still accepted by GitHub
+func (c *Client) ValidateToken() (string, error) {
+	ctx, span := c.startSpan("ValidateToken")
+	defer span.End()
+
+	user, _, err := call(c, ctx, func() (*github.User, *github.Response, error) {
+		return c.client.Users.Get(ctx, "")
+	})
+	if err != nil {
+		return "", recordError(span, fmt.Errorf("failed to validate GitHub token: %w", err))
+	}
+	return user.GetLogin(), nil
+}
+
+// IsUnauthorized reports whether err is a GitHub 401 (revoked, expired or invalid token)
+func IsUnauthorized(err error) bool {
+	var ghErr *github.ErrorResponse
+	if errors.As(err, &ghErr) && ghErr.Response != nil {
+		return ghErr.Response.StatusCode == http.StatusUnauthorized
+	}
+	return false
+}
+
+// IsConflict reports whether err is a GitHub 409 (e.g. listing commits of an empty repository)
+func IsConflict(err error) bool {
+	var ghErr *github.ErrorResponse
+	if errors.As(err, &ghErr) && ghErr.Response != nil {
+		return ghErr.Response.StatusCode == http.StatusConflict
+	}
+	return false
+}
+
+// ListOpenPullRequests lists every open PR in a repository, following pagination
+func (c *Client) ListOpenPullRequests(repoName string) ([]*github.PullRequest, error) {
+	ctx, span := c.startSpan("ListOpenPullRequests", attribute.String("github.repo", repoName))
+	defer span.End()
+
+	opts := &github.PullRequestListOptions{
+		State:       "open",
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+
+	var all []*github.PullRequest
+	for {
+		prs, resp, err := call(c, ctx, func() ([]*github.PullRequest, *github.Response, error) {
+			return c.client.PullRequests.List(ctx, c.ownerOf(repoName), repoName, opts)
+		})
+		if err != nil {
+			return nil, recordError(span, fmt.Errorf("failed to list open PRs for repo %s: %w", repoName, err))
+		}
+		all = append(all, prs...)
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	return all, nil
+}
+
+// CommentOnPR posts a comment on a pull request's conversation
+func (c *Client) CommentOnPR(repoName string, prNumber int, body string) error {
+	ctx, span := c.startSpan("CommentOnPR", attribute.String("github.repo", repoName), attribute.Int("github.pr", prNumber))
+	defer span.End()
+
+	// PR conversation comments are issue comments in the GitHub API
+	_, _, err := call(c, ctx, func() (*github.IssueComment, *github.Response, error) {
+		return c.client.Issues.CreateComment(ctx, c.ownerOf(repoName), repoName, prNumber, &github.IssueComment{
+			Body: github.String(body),
+		})
+	})
+	if err != nil {
+		return recordError(span, fmt.Errorf("failed to comment on PR #%d in %s: %w", prNumber, repoName, err))
+	}
+	return nil
+}
+
+// IsCollaborator reports whether login is a collaborator on the repository
+func (c *Client) IsCollaborator(repoName, login string) (bool, error) {
+	ctx, span := c.startSpan("IsCollaborator", attribute.String("github.repo", repoName), attribute.String("github.user", login))
+	defer span.End()
+
+	ok, _, err := call(c, ctx, func() (bool, *github.Response, error) {
+		return c.client.Repositories.IsCollaborator(ctx, c.ownerOf(repoName), repoName, login)
+	})
+	if err != nil {
+		return false, recordError(span, fmt.Errorf("failed to check collaborator %s on %s: %w", login, repoName, err))
+	}
+	return ok, nil
+}
+
+// PRCommentExists reports whether any comment on the PR contains marker
+func (c *Client) PRCommentExists(repoName string, prNumber int, marker string) (bool, error) {
+	ctx, span := c.startSpan("PRCommentExists", attribute.String("github.repo", repoName), attribute.Int("github.pr", prNumber))
+	defer span.End()
+
+	opts := &github.IssueListCommentsOptions{ListOptions: github.ListOptions{PerPage: 100}}
+	for {
+		comments, resp, err := call(c, ctx, func() ([]*github.IssueComment, *github.Response, error) {
+			return c.client.Issues.ListComments(ctx, c.ownerOf(repoName), repoName, prNumber, opts)
+		})
+		if err != nil {
+			return false, recordError(span, fmt.Errorf("failed to list comments on PR #%d in %s: %w", prNumber, repoName, err))
+		}
+		for _, comment := range comments {
+			if strings.Contains(comment.GetBody(), marker) {
+				return true, nil
+			}
+		}
+
+		if resp.NextPage == 0 {
+			return false, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// FindOpenPRForBranch returns the open PR whose head is branch, or nil if there is none
+func (c *Client) FindOpenPRForBranch(repoName, branch string) (*github.PullRequest, error) {
+	ctx, span := c.startSpan("FindOpenPRForBranch", attribute.String("github.repo", repoName), attribute.String("github.branch", branch))
+	defer span.End()
+
+	prs, _, err := call(c, ctx, func() ([]*github.PullRequest, *github.Response, error) {
+		return c.client.PullRequests.List(ctx, c.ownerOf(repoName), repoName, &github.PullRequestListOptions{
+			State: "open",
+			Head:  c.ownerOf(repoName) + ":" + branch,
+		})
+	})
+	if err != nil {
+		return nil, recordError(span, fmt.Errorf("failed to list PRs for branch %s: %w", branch, err))
+	}
+	if len(prs) == 0 {
+		return nil, nil
+	}
+	return prs[0], nil
+}
+
+// ListMergedPullRequestsSince lists the PRs in a repository merged at or after since. Closed PRs
+// are read most recently updated first, so paging stops once they predate since.
+func (c *Client) ListMergedPullRequestsSince(repoName string, since time.Time) ([]*github.PullRequest, error) {
+	ctx, span := c.startSpan("ListMergedPullRequestsSince", attribute.String("github.repo", repoName))
+	defer span.End()
+
+	opts := &github.PullRequestListOptions{
+		State:       "closed",
+		Sort:        "updated",
+		Direction:   "desc",
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+
+	var merged []*github.PullRequest
+	for {
+		prs, resp, err := call(c, ctx, func() ([]*github.PullRequest, *github.Response, error) {
+			return c.client.PullRequests.List(ctx, c.ownerOf(repoName), repoName, opts)
+		})
+		if err != nil {
+			return nil, recordError(span, fmt.Errorf("failed to list closed PRs for repo %s: %w", repoName, err))
+		}
+		for _, pr := range prs {
+			// A merge updates the PR, so nothing older than since can have been merged after it
+			if pr.GetUpdatedAt().Before(since) {
+				return merged, nil
+			}
+			if pr.MergedAt != nil && !pr.GetMergedAt().Before(since) {
+				merged = append(merged, pr)
+			}
+		}
+
+		if resp.NextPage == 0 {
+			return merged, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// ListCheckRuns lists the latest check run of every check on a commit
+func (c *Client) ListCheckRuns(repoName, sha string) ([]*github.CheckRun, error) {
+	ctx, span := c.startSpan("ListCheckRuns", attribute.String("github.repo", repoName), attribute.String("github.sha", sha))
+	defer span.End()
+
+	opts := &github.ListCheckRunsOptions{Filter: github.String("latest"), ListOptions: github.ListOptions{PerPage: 100}}
+
+	var all []*github.CheckRun
+	for {
+		results, resp, err := call(c, ctx, func() (*github.ListCheckRunsResults, *github.Response, error) {
+			return c.client.Checks.ListCheckRunsForRef(ctx, c.ownerOf(repoName), repoName, sha, opts)
+		})
+		if err != nil {
+			return nil, recordError(span, fmt.Errorf("failed to list check runs for %s in %s: %w", sha, repoName, err))
+		}
+		all = append(all, results.CheckRuns...)
+
+		if resp.NextPage == 0 {
+			return all, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// ListPullRequestsWithCommit lists the PRs that contain a commit (e.g. a deployed SHA)
+func (c *Client) ListPullRequestsWithCommit(repoName, sha string) ([]*github.PullRequest, error) {
+	ctx, span := c.startSpan("ListPullRequestsWithCommit", attribute.String("github.repo", repoName), attribute.String("github.sha", sha))
+	defer span.End()
+
+	opts := &github.ListOptions{PerPage: 100}
+
+	var all []*github.PullRequest
+	for {
+		prs, resp, err := call(c, ctx, func() ([]*github.PullRequest, *github.Response, error) {
+			return c.client.PullRequests.ListPullRequestsWithCommit(ctx, c.ownerOf(repoName), repoName, sha, opts)
+		})
+		if err != nil {
+			return nil, recordError(span, fmt.Errorf("failed to list PRs for commit %s in %s: %w", sha, repoName, err))
+		}
+		all = append(all, prs...)
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	return all, nil
+}
+
+// GetCompare fetches the aggregate diff between two commits in one call (e.g. a push's before..after range)
+func (c *Client) GetCompare(repoName, base, head string) (*CompareInfo, error) {
+	ctx, span := c.startSpan("GetCompare", attribute.String("github.repo", repoName),
+		attribute.String("github.base", base), attribute.String("github.head", head))
+	defer span.End()
+
+	comparison, _, err := call(c, ctx, func() (*github.CommitsComparison, *github.Response, error) {
+		return c.client.Repositories.CompareCommits(ctx, c.ownerOf(repoName), repoName, base, head, &github.ListOptions{PerPage: 100})
+	})
+	if err != nil {
+		return nil, recordError(span, fmt.Errorf("failed to compare %s...%s: %w", shortRef(base), shortRef(head), err))
+	}
+
+	files, ignored := c.filterFiles(comparison.Files)
+	info := &CompareInfo{
+		Status:       comparison.GetStatus(),
+		AheadBy:      comparison.GetAheadBy(),
+		BehindBy:     comparison.GetBehindBy(),
+		TotalCommits: comparison.GetTotalCommits(),
+		Files:        files,
+		IgnoredFiles: ignored,
+	}
+	// Count the kept files only, so the totals agree with Files
+	info.Additions, info.Deletions = sumChanges(files)
+
+	var diffBuilder strings.Builder
+	diffBuilder.WriteString(fmt.Sprintf("=== COMPARE DIFF: %s...%s ===\n", shortRef(base), shortRef(head)))
+	diffBuilder.WriteString(fmt.Sprintf("Commits: %d, Total files changed: %d\n", info.TotalCommits, len(info.Files)))
+	if ignored > 0 {
+		diffBuilder.WriteString(fmt.Sprintf("Ignored files: %d (matched DIFF_IGNORE_GLOBS)\n", ignored))
+	}
+	diffBuilder.WriteString(fmt.Sprintf("Additions: +%d, Deletions: -%d\n", info.Additions, info.Deletions))
+	diffBuilder.WriteString("=" + strings.Repeat("=", 50) + "\n\n")
+	writeFileDiffs(&diffBuilder, info.Files)
+	info.DiffContent = diffBuilder.String()
+
+	return info, nil
+}
+
+// shortRef abbreviates a SHA for messages, leaving branch names untouched
+func shortRef(ref string) string {
+	if len(ref) == 40 {
+		return ref[:8]
+	}
+	return ref
+}
@@ -0,0 +1,141 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v56/github"
+
+	"github_integration/internal/retry"
+)
+
+// ErrSecondaryRateLimited matches errors caused by GitHub's secondary (abuse) rate limits
+var ErrSecondaryRateLimited = errors.New("github secondary rate limit exceeded")
+
+// ErrHookExists is returned when a repository already has a webhook for the same URL
+var ErrHookExists = errors.New("webhook already exists")
+
+// isHookExists reports whether err is GitHub's 422 "Hook already exists on this repository",
+// as opposed to other validation failures (bad URL, unknown event...)
+func isHookExists(err error) bool {
+	var ghErr *github.ErrorResponse
+	if !errors.As(err, &ghErr) || ghErr.Response == nil || ghErr.Response.StatusCode != http.StatusUnprocessableEntity {
+		return false
+	}
+	for _, detail := range ghErr.Errors {
+		if strings.Contains(strings.ToLower(detail.Message), "hook already exists") {
+			return true
+		}
+	}
+	return false
+}
+
+// ErrNoRepoAccess is returned when GitHub answers 404 for a repository the token can't see, which
+// for a private repository usually means missing permissions rather than a deleted resource
+var ErrNoRepoAccess = errors.New("token has no access to the repository")
+
+// isNotFound reports whether err is a GitHub 404, or a GraphQL response's NOT_FOUND error
+func isNotFound(err error) bool {
+	var gqlErrs GraphQLErrors
+	if errors.As(err, &gqlErrs) {
+		for _, gqlErr := range gqlErrs {
+			if gqlErr.Type == "NOT_FOUND" {
+				return true
+			}
+		}
+		return false
+	}
+	var ghErr *github.ErrorResponse
+	return errors.As(err, &ghErr) && ghErr.Response != nil && ghErr.Response.StatusCode == http.StatusNotFound
+}
+
+// explainNotFound tells a 404 (REST or GraphQL) caused by missing repository access apart from a
+// genuinely missing resource: GitHub hides private repositories the token can't read behind 404s too
+func (c *Client) explainNotFound(ctx context.Context, repoName string, err error) error {
+	if !c.diagnoseNotFound || !isNotFound(err) {
+		return err
+	}
+	_, _, repoErr := c.client.Repositories.Get(ctx, c.ownerOf(repoName), repoName)
+	if !isNotFound(repoErr) {
+		return err
+	}
+	return fmt.Errorf("%w %s/%s (GitHub reported it not found; check the token's repository scope or the GitHub App installation): %v",
+		ErrNoRepoAccess, c.ownerOf(repoName), repoName, err)
+}
+
+// defaultSecondaryRetryAfter is GitHub's documented minimum wait when no Retry-After is sent
+const defaultSecondaryRetryAfter = time.Minute
+
+// SecondaryRateLimitError is a retryable secondary rate limit response with its advised delay
+type SecondaryRateLimitError struct {
+	Wait time.Duration
+	Err  error
+}
+
+func (e *SecondaryRateLimitError) Error() string             { return e.Err.Error() }
+func (e *SecondaryRateLimitError) Unwrap() error             { return e.Err }
+func (e *SecondaryRateLimitError) Is(target error) bool      { return target == ErrSecondaryRateLimited }
+func (e *SecondaryRateLimitError) Retryable() bool           { return true }
+func (e *SecondaryRateLimitError) RetryAfter() time.Duration { return e.Wait }
+
+// classifyError marks GitHub errors that are worth retrying. Secondary rate limits are retried for
+// any request, since GitHub rejected it unprocessed; 5xx and network errors only for reads, as a
+// write that timed out may still have been applied and repeating it would duplicate a hook or comment.
+// repeatable marks a write that is safe to repeat anyway, so it is retried like a read.
+func classifyError(err error, repeatable bool) error {
+	if err == nil {
+		return nil
+	}
+
+	var abuseErr *github.AbuseRateLimitError
+	if errors.As(err, &abuseErr) {
+		wait := defaultSecondaryRetryAfter
+		if abuseErr.RetryAfter != nil {
+			wait = *abuseErr.RetryAfter
+		}
+		return &SecondaryRateLimitError{Wait: wait, Err: err}
+	}
+
+	var ghErr *github.ErrorResponse
+	if errors.As(err, &ghErr) && ghErr.Response != nil {
+		status := ghErr.Response.StatusCode
+		retryAfter := ghErr.Response.Header.Get("Retry-After")
+
+		if status == http.StatusForbidden &&
+			(strings.Contains(strings.ToLower(ghErr.Message), "secondary rate limit") || retryAfter != "") {
+			wait := defaultSecondaryRetryAfter
+			if seconds, convErr := strconv.Atoi(retryAfter); convErr == nil {
+				wait = time.Duration(seconds) * time.Second
+			}
+			return &SecondaryRateLimitError{Wait: wait, Err: err}
+		}
+		if status >= http.StatusInternalServerError && (repeatable || (ghErr.Response.Request != nil && isIdempotent(ghErr.Response.Request.Method))) {
+			return retry.Transient(err)
+		}
+		return err
+	}
+
+	var netErr net.Error
+	var urlErr *url.Error
+	if errors.As(err, &netErr) && errors.As(err, &urlErr) && (repeatable || isIdempotent(urlErr.Op)) {
+		return retry.Transient(err)
+	}
+	return err
+}
+
+// isIdempotent reports whether a request with method can be repeated without side effects
+// (url.Error reports the method as e.g. "Get")
+func isIdempotent(method string) bool {
+	switch strings.ToUpper(method) {
+	case http.MethodGet, http.MethodHead:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,64 @@
+package github
+
+import (
+	"errors"
+	"fmt"
+)
+
+// EventType is a webhook event name as sent in GitHub's X-GitHub-Event header
+type EventType string
+
+// Event types the integration subscribes to or handles
+const (
+	EventPing                     EventType = "ping"
+	EventPush                     EventType = "push"
+	EventPullRequest              EventType = "pull_request"
+	EventIssues                   EventType = "issues"
+	EventIssueComment             EventType = "issue_comment"
+	EventCommitComment            EventType = "commit_comment"
+	EventRepository               EventType = "repository"
+	EventRelease                  EventType = "release"
+	EventDeploymentStatus         EventType = "deployment_status"
+	EventCheckRun                 EventType = "check_run"
+	EventCheckSuite               EventType = "check_suite"
+	EventInstallation             EventType = "installation"
+	EventInstallationRepositories EventType = "installation_repositories"
+)
+
+var (
+	// ErrMissingEventType is returned for a delivery without an X-GitHub-Event header
+	ErrMissingEventType = errors.New("missing X-GitHub-Event header")
+	// ErrUnknownEventType is returned for event types the integration has no handler for;
+	// such deliveries are still recorded by the audit fallback
+	ErrUnknownEventType = errors.New("unknown GitHub event type")
+)
+
+// knownEventTypes is every EventType constant
+var knownEventTypes = map[EventType]bool{
+	EventPing:                     true,
+	EventPush:                     true,
+	EventPullRequest:              true,
+	EventIssues:                   true,
+	EventIssueComment:             true,
+	EventCommitComment:            true,
+	EventRepository:               true,
+	EventRelease:                  true,
+	EventDeploymentStatus:         true,
+	EventCheckRun:                 true,
+	EventCheckSuite:               true,
+	EventInstallation:             true,
+	EventInstallationRepositories: true,
+}
+
+// ParseEventType reads an X-GitHub-Event header value. Unknown types are returned along with
+// ErrUnknownEventType so callers can still route them to a fallback.
+func ParseEventType(header string) (EventType, error) {
+	if header == "" {
+		return "", ErrMissingEventType
+	}
+	eventType := EventType(header)
+	if !knownEventTypes[eventType] {
+		return eventType, fmt.Errorf("%w: %s", ErrUnknownEventType, header)
+	}
+	return eventType, nil
+}
@@ -0,0 +1,126 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/google/go-github/v56/github"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// graphQLError is one entry of a GraphQL response's errors array
+type graphQLError struct {
+	Message string `json:"message"`
+	// Type classifies the error, e.g. NOT_FOUND for a repository or object the token can't see
+	Type string `json:"type"`
+}
+
+// GraphQLErrors is returned when GitHub answers a GraphQL query with errors
+type GraphQLErrors []graphQLError
+
+func (e GraphQLErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Message
+	}
+	return "graphql: " + strings.Join(messages, "; ")
+}
+
+// graphQL runs a query against GitHub's GraphQL API, decoding the data object into out
+func (c *Client) graphQL(ctx context.Context, query string, variables map[string]interface{}, out interface{}) error {
+	var body struct {
+		Data   json.RawMessage `json:"data"`
+		Errors GraphQLErrors   `json:"errors"`
+	}
+	if _, _, err := call(c, ctx, func() (struct{}, *github.Response, error) {
+		// Build the request per attempt so retries resend the body
+		req, err := c.client.NewRequest("POST", "graphql", map[string]interface{}{
+			"query":     query,
+			"variables": variables,
+		})
+		if err != nil {
+			return struct{}{}, nil, err
+		}
+		resp, err := c.client.Do(ctx, req, &body)
+		return struct{}{}, resp, err
+	}); err != nil {
+		return err
+	}
+	if len(body.Errors) > 0 {
+		return body.Errors
+	}
+	return json.Unmarshal(body.Data, out)
+}
+
+const reviewThreadsQuery = `query($owner: String!, $name: String!, $number: Int!, $cursor: String) {
+  repository(owner: $owner, name: $name) {
+    pullRequest(number: $number) {
+      reviewThreads(first: 100, after: $cursor) {
+        nodes { isResolved }
+        pageInfo { hasNextPage endCursor }
+      }
+    }
+  }
+}`
+
+// ReviewThreadSummary counts a PR's review threads by resolution state
+type ReviewThreadSummary struct {
+	Total      int
+	Unresolved int
+}
+
+// GetReviewThreadSummary counts resolved and unresolved review threads on a PR
+// (resolution state is only exposed through the GraphQL API)
+func (c *Client) GetReviewThreadSummary(repoName string, prNumber int) (ReviewThreadSummary, error) {
+	ctx, span := c.startSpan("GetReviewThreadSummary", attribute.String("github.repo", repoName), attribute.Int("github.pr", prNumber))
+	defer span.End()
+
+	var (
+		summary ReviewThreadSummary
+		cursor  *string
+	)
+	for {
+		var data struct {
+			Repository struct {
+				PullRequest struct {
+					ReviewThreads struct {
+						Nodes []struct {
+							IsResolved bool `json:"isResolved"`
+						} `json:"nodes"`
+						PageInfo struct {
+							HasNextPage bool   `json:"hasNextPage"`
+							EndCursor   string `json:"endCursor"`
+						} `json:"pageInfo"`
+					} `json:"reviewThreads"`
+				} `json:"pullRequest"`
+			} `json:"repository"`
+		}
+		err := c.graphQL(ctx, reviewThreadsQuery, map[string]interface{}{
+			"owner":  c.ownerOf(repoName),
+			"name":   repoName,
+			"number": prNumber,
+			"cursor": cursor,
+		}, &data)
+		if err != nil {
+			return ReviewThreadSummary{}, recordError(span, fmt.Errorf("failed to get review threads for PR #%d: %w", prNumber, err))
+		}
+
+		threads := data.Repository.PullRequest.ReviewThreads
+		for _, thread := range threads.Nodes {
+			summary.Total++
+			if !thread.IsResolved {
+				summary.Unresolved++
+			}
+		}
+
+		if !threads.PageInfo.HasNextPage {
+			break
+		}
+		cursor = &threads.PageInfo.EndCursor
+	}
+
+	span.SetAttributes(attribute.Int("github.review_threads", summary.Total), attribute.Int("github.review_threads_unresolved", summary.Unresolved))
+	return summary, nil
+}
@@ -0,0 +1,96 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// repoOwners records repositories that moved to another owner, shared by client copies
+type repoOwners struct {
+	mu     sync.RWMutex
+	owners map[string]string
+	// path is the file the overrides persist to (empty keeps them in memory only)
+	path string
+}
+
+// SetRepoOwnersFile loads transferred-repository owners from path and saves every later change
+// there, so a restart doesn't send API calls back to the old owner
+func (c *Client) SetRepoOwnersFile(path string) error {
+	c.owners.mu.Lock()
+	defer c.owners.mu.Unlock()
+
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &c.owners.owners); err != nil {
+			return fmt.Errorf("invalid repository owners file %s: %w", path, err)
+		}
+	}
+	c.owners.path = path
+	return nil
+}
+
+// save writes the overrides to their file (replacing it atomically); the caller holds mu
+func (o *repoOwners) save() error {
+	if o.path == "" {
+		return nil
+	}
+	data, err := json.Marshal(o.owners)
+	if err != nil {
+		return err
+	}
+	tmp := o.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("failed to save repository owners: %w", err)
+	}
+	if err := os.Rename(tmp, o.path); err != nil {
+		return fmt.Errorf("failed to save repository owners: %w", err)
+	}
+	return nil
+}
+
+// ownerOf returns the account owning repoName: the org unless the repo was transferred away
+func (c *Client) ownerOf(repoName string) string {
+	c.owners.mu.RLock()
+	defer c.owners.mu.RUnlock()
+	if owner, ok := c.owners.owners[repoName]; ok {
+		return owner
+	}
+	return c.org
+}
+
+// SetRepoOwner makes API calls for repoName use owner (e.g. after a transfer); the org clears it.
+// The override applies even when saving it fails.
+func (c *Client) SetRepoOwner(repoName, owner string) error {
+	c.owners.mu.Lock()
+	defer c.owners.mu.Unlock()
+	if owner == "" || owner == c.org {
+		if _, ok := c.owners.owners[repoName]; !ok {
+			return nil
+		}
+		delete(c.owners.owners, repoName)
+	} else {
+		c.owners.owners[repoName] = owner
+	}
+	return c.owners.save()
+}
+
+// RenameRepo carries state kept under a repository's old name (cache, owner) over to its new name
+func (c *Client) RenameRepo(oldName, newName string) error {
+	c.InvalidateRepository(oldName)
+	c.InvalidateRepository(newName)
+
+	c.owners.mu.Lock()
+	defer c.owners.mu.Unlock()
+	owner, ok := c.owners.owners[oldName]
+	if !ok {
+		return nil
+	}
+	c.owners.owners[newName] = owner
+	delete(c.owners.owners, oldName)
+	return c.owners.save()
+}
@@ -0,0 +1,166 @@
+package github
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v56/github"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// prDetailsQuery fetches a PR, its changed files and its reviews in one round-trip. The first
+// 100 files and reviews are fetched, which already covers more than the REST path's default page.
+const prDetailsQuery = `query($owner: String!, $name: String!, $number: Int!) {
+  repository(owner: $owner, name: $name) {
+    pullRequest(number: $number) {
+      number title body state url merged
+      additions deletions changedFiles createdAt updatedAt
+      author { login }
+      headRefName baseRefName
+      labels(first: 100) { nodes { name } }
+      files(first: 100) { nodes { path additions deletions changeType } }
+      reviews(first: 100) { nodes { state body submittedAt url author { login } } }
+    }
+  }
+}`
+
+// graphQLChangeTypes maps GraphQL's PatchStatus to the REST API's file status names
+var graphQLChangeTypes = map[string]string{
+	"ADDED":    "added",
+	"DELETED":  "removed",
+	"MODIFIED": "modified",
+	"RENAMED":  "renamed",
+	"COPIED":   "copied",
+	"CHANGED":  "changed",
+}
+
+type graphQLActor struct {
+	Login string `json:"login"`
+}
+
+type graphQLPullRequest struct {
+	Number       int          `json:"number"`
+	Title        string       `json:"title"`
+	Body         string       `json:"body"`
+	State        string       `json:"state"`
+	URL          string       `json:"url"`
+	Merged       bool         `json:"merged"`
+	Additions    int          `json:"additions"`
+	Deletions    int          `json:"deletions"`
+	ChangedFiles int          `json:"changedFiles"`
+	CreatedAt    time.Time    `json:"createdAt"`
+	UpdatedAt    time.Time    `json:"updatedAt"`
+	Author       graphQLActor `json:"author"`
+	HeadRefName  string       `json:"headRefName"`
+	BaseRefName  string       `json:"baseRefName"`
+	Labels       struct {
+		Nodes []struct {
+			Name string `json:"name"`
+		} `json:"nodes"`
+	} `json:"labels"`
+	Files struct {
+		Nodes []struct {
+			Path       string `json:"path"`
+			Additions  int    `json:"additions"`
+			Deletions  int    `json:"deletions"`
+			ChangeType string `json:"changeType"`
+		} `json:"nodes"`
+	} `json:"files"`
+	Reviews struct {
+		Nodes []struct {
+			State       string       `json:"state"`
+			Body        string       `json:"body"`
+			SubmittedAt time.Time    `json:"submittedAt"`
+			URL         string       `json:"url"`
+			Author      graphQLActor `json:"author"`
+		} `json:"nodes"`
+	} `json:"reviews"`
+}
+
+// GetPullRequestDetailsGraphQL gets the same details as GetPullRequestDetails with a single
+// GraphQL query. File patches are not available through GraphQL and are left empty.
+func (c *Client) GetPullRequestDetailsGraphQL(repoName string, prNumber int) (*PRDetails, error) {
+	ctx, span := c.startSpan("GetPullRequestDetailsGraphQL", attribute.String("github.repo", repoName), attribute.Int("github.pr", prNumber))
+	defer span.End()
+
+	var data struct {
+		Repository struct {
+			PullRequest *graphQLPullRequest `json:"pullRequest"`
+		} `json:"repository"`
+	}
+	err := c.graphQL(ctx, prDetailsQuery, map[string]interface{}{
+		"owner":  c.ownerOf(repoName),
+		"name":   repoName,
+		"number": prNumber,
+	}, &data)
+	if err != nil {
+		return nil, recordError(span, fmt.Errorf("failed to get PR details: %w", c.explainNotFound(ctx, repoName, err)))
+	}
+	if data.Repository.PullRequest == nil {
+		return nil, recordError(span, fmt.Errorf("failed to get PR details: PR #%d not found in %s", prNumber, repoName))
+	}
+
+	details := c.toPRDetails(data.Repository.PullRequest)
+	details.PullRequest.Base.Repo = &github.Repository{Name: github.String(repoName), Owner: &github.User{Login: github.String(c.ownerOf(repoName))}}
+	return details, nil
+}
+
+// toPRDetails converts a GraphQL pull request into the REST-shaped PRDetails
+func (c *Client) toPRDetails(gpr *graphQLPullRequest) *PRDetails {
+	// REST reports merged PRs as "closed" with merged=true
+	state := strings.ToLower(gpr.State)
+	if gpr.Merged {
+		state = "closed"
+	}
+
+	pr := &github.PullRequest{
+		Number:       github.Int(gpr.Number),
+		Title:        github.String(gpr.Title),
+		Body:         github.String(gpr.Body),
+		State:        github.String(state),
+		HTMLURL:      github.String(gpr.URL),
+		Merged:       github.Bool(gpr.Merged),
+		Additions:    github.Int(gpr.Additions),
+		Deletions:    github.Int(gpr.Deletions),
+		ChangedFiles: github.Int(gpr.ChangedFiles),
+		CreatedAt:    &github.Timestamp{Time: gpr.CreatedAt},
+		UpdatedAt:    &github.Timestamp{Time: gpr.UpdatedAt},
+		User:         &github.User{Login: github.String(gpr.Author.Login)},
+		Head:         &github.PullRequestBranch{Ref: github.String(gpr.HeadRefName)},
+		Base:         &github.PullRequestBranch{Ref: github.String(gpr.BaseRefName)},
+	}
+	for _, label := range gpr.Labels.Nodes {
+		pr.Labels = append(pr.Labels, &github.Label{Name: github.String(label.Name)})
+	}
+
+	prFiles := make([]*github.CommitFile, 0, len(gpr.Files.Nodes))
+	for _, file := range gpr.Files.Nodes {
+		prFiles = append(prFiles, &github.CommitFile{
+			Filename:  github.String(file.Path),
+			Additions: github.Int(file.Additions),
+			Deletions: github.Int(file.Deletions),
+			Changes:   github.Int(file.Additions + file.Deletions),
+			Status:    github.String(graphQLChangeTypes[file.ChangeType]),
+		})
+	}
+
+	reviews := make([]*github.PullRequestReview, 0, len(gpr.Reviews.Nodes))
+	for _, review := range gpr.Reviews.Nodes {
+		reviews = append(reviews, &github.PullRequestReview{
+			State:       github.String(review.State),
+			Body:        github.String(review.Body),
+			SubmittedAt: &github.Timestamp{Time: review.SubmittedAt},
+			HTMLURL:     github.String(review.URL),
+			User:        &github.User{Login: github.String(review.Author.Login)},
+		})
+	}
+
+	files, ignored := c.filterFiles(prFiles)
+	return &PRDetails{
+		PullRequest:  pr,
+		Files:        files,
+		IgnoredFiles: ignored,
+		Reviews:      reviews,
+	}
+}
@@ -0,0 +1,68 @@
+package github
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v56/github"
+)
+
+// rateLimitCacheTTL keeps GetRateLimits from spending budget on checking the budget
+const rateLimitCacheTTL = 30 * time.Second
+
+// RateLimit is the state of one GitHub rate-limit bucket
+type RateLimit struct {
+	Limit     int       `json:"limit"`
+	Remaining int       `json:"remaining"`
+	Reset     time.Time `json:"reset"`
+}
+
+// RateLimits is the integration's current standing against GitHub's rate limits
+type RateLimits struct {
+	Core      RateLimit `json:"core"`
+	Search    RateLimit `json:"search"`
+	GraphQL   RateLimit `json:"graphql"`
+	FetchedAt time.Time `json:"fetched_at"`
+}
+
+// rateLimitCache remembers the last rate-limit snapshot; it is shared by client copies
+type rateLimitCache struct {
+	mu     sync.Mutex
+	limits *RateLimits
+}
+
+// GetRateLimits returns the core, search and GraphQL rate-limit state (cached for 30 seconds)
+func (c *Client) GetRateLimits() (*RateLimits, error) {
+	c.rateLimits.mu.Lock()
+	defer c.rateLimits.mu.Unlock()
+	if cached := c.rateLimits.limits; cached != nil && time.Since(cached.FetchedAt) < rateLimitCacheTTL {
+		return cached, nil
+	}
+
+	ctx, span := c.startSpan("GetRateLimits")
+	defer span.End()
+
+	limits, _, err := call(c, ctx, func() (*github.RateLimits, *github.Response, error) {
+		return c.client.RateLimits(ctx)
+	})
+	if err != nil {
+		return nil, recordError(span, fmt.Errorf("failed to get rate limits: %w", err))
+	}
+
+	c.rateLimits.limits = &RateLimits{
+		Core:      toRateLimit(limits.Core),
+		Search:    toRateLimit(limits.Search),
+		GraphQL:   toRateLimit(limits.GraphQL),
+		FetchedAt: time.Now(),
+	}
+	return c.rateLimits.limits, nil
+}
+
+// toRateLimit converts a go-github rate, which is nil for buckets GitHub didn't report
+func toRateLimit(rate *github.Rate) RateLimit {
+	if rate == nil {
+		return RateLimit{}
+	}
+	return RateLimit{Limit: rate.Limit, Remaining: rate.Remaining, Reset: rate.Reset.Time}
+}
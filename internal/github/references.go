@@ -0,0 +1,51 @@
+package github
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// closingReference matches GitHub's closing keywords followed by "#12" or a full issue URL,
+// capturing the URL's owner and repository
+var closingReference = regexp.MustCompile(`(?i)\b(?:close[sd]?|fix(?:e[sd])?|resolve[sd]?):?\s+(?:#(\d+)|https?://[^\s/]+/([\w.-]+)/([\w.-]+)/issues/(\d+))\b`)
+
+// dependencyReference matches "Depends on #12" or a full PR URL, as used for stacked PRs
+var dependencyReference = regexp.MustCompile(`(?i)\bdepends\s+on:?\s+(?:#(\d+)|https?://[^\s/]+/([\w.-]+)/([\w.-]+)/pull/(\d+))\b`)
+
+// ParseDependencyReferences returns the PR numbers in its own repository a PR body says it depends
+// on ("Depends on #12"), in order of first mention. URLs into another repository are dropped; an
+// empty owner matches any owner.
+func ParseDependencyReferences(body, owner, repo string) []int {
+	return parseNumberedReferences(dependencyReference, body, owner, repo)
+}
+
+// ParseClosingReferences returns the issue numbers a PR body closes in its own repository
+// ("Closes #12", "fixes https://github.com/owner/repo/issues/12"), in order of first mention.
+// URLs into another repository are dropped; an empty owner matches any owner.
+func ParseClosingReferences(body, owner, repo string) []int {
+	return parseNumberedReferences(closingReference, body, owner, repo)
+}
+
+// parseNumberedReferences returns the distinct numbers captured by pattern's "#N" or URL group.
+// With repo set, URLs into any other owner/repo are skipped.
+func parseNumberedReferences(pattern *regexp.Regexp, body, owner, repo string) []int {
+	var numbers []int
+	seen := make(map[int]bool)
+	for _, match := range pattern.FindAllStringSubmatch(body, -1) {
+		ref := match[1]
+		if ref == "" {
+			if repo != "" && (!strings.EqualFold(match[3], repo) || (owner != "" && !strings.EqualFold(match[2], owner))) {
+				continue
+			}
+			ref = match[4]
+		}
+		n, err := strconv.Atoi(ref)
+		if err != nil || seen[n] {
+			continue
+		}
+		seen[n] = true
+		numbers = append(numbers, n)
+	}
+	return numbers
+}
@@ -0,0 +1,70 @@
+package github
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v56/github"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// teamRepoCacheTTL is how long a team's repository list is reused before it is fetched again
+const teamRepoCacheTTL = 30 * time.Minute
+
+// teamRepoCache remembers which repositories each team can access; it is shared by client copies
+type teamRepoCache struct {
+	mu      sync.Mutex
+	entries map[string]teamRepoEntry
+}
+
+type teamRepoEntry struct {
+	repos     map[string]bool
+	expiresAt time.Time
+}
+
+// TeamHasRepo reports whether the org team with slug has access to repoName (cached for 30 minutes)
+func (c *Client) TeamHasRepo(slug, repoName string) (bool, error) {
+	c.teams.mu.Lock()
+	entry, ok := c.teams.entries[slug]
+	c.teams.mu.Unlock()
+	if ok && time.Now().Before(entry.expiresAt) {
+		return entry.repos[repoName], nil
+	}
+
+	repos, err := c.listTeamRepos(slug)
+	if err != nil {
+		return false, err
+	}
+
+	c.teams.mu.Lock()
+	c.teams.entries[slug] = teamRepoEntry{repos: repos, expiresAt: time.Now().Add(teamRepoCacheTTL)}
+	c.teams.mu.Unlock()
+	return repos[repoName], nil
+}
+
+// listTeamRepos fetches every repository name the team has access to
+func (c *Client) listTeamRepos(slug string) (map[string]bool, error) {
+	ctx, span := c.startSpan("ListTeamRepos", attribute.String("github.team", slug))
+	defer span.End()
+
+	repos := make(map[string]bool)
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		page, resp, err := call(c, ctx, func() ([]*github.Repository, *github.Response, error) {
+			return c.client.Teams.ListTeamReposBySlug(ctx, c.org, slug, opts)
+		})
+		if err != nil {
+			return nil, recordError(span, fmt.Errorf("failed to list repositories of team %s: %w", slug, err))
+		}
+		for _, repo := range page {
+			repos[repo.GetName()] = true
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return repos, nil
+}
@@ -6,7 +6,9 @@ import "github.com/google/go-github/v56/github"
 type PRDetails struct {
 	PullRequest *github.PullRequest
 	Files       []*github.CommitFile
-	Reviews     []*github.PullRequestReview
+	// IgnoredFiles counts changed files hidden by the diff ignore globs
+	IgnoredFiles int
+	Reviews      []*github.PullRequestReview
 }
 
 // RepoCreationInfo contains detailed information about newly created repository
@@ -36,15 +38,37 @@ type CommitInfo struct {
 	Repository   string
 	Branch       string
 	DiffContent  string
+	// IsMerge is set for commits with more than one parent
+	IsMerge bool
+}
+
+// CompareInfo contains the aggregate diff between two commits
+type CompareInfo struct {
+	// Status is "ahead" when head descends from base; "diverged" means history was rewritten
+	Status       string
+	AheadBy      int
+	BehindBy     int
+	TotalCommits int
+	Files        []*github.CommitFile
+	IgnoredFiles int
+	// Additions and Deletions total the kept Files, leaving out ignored ones
+	Additions   int
+	Deletions   int
+	DiffContent string
+}
+
+// Connected reports whether head is a straight descendant of base (not a force push)
+func (c *CompareInfo) Connected() bool {
+	return c.Status == "ahead" || c.Status == "identical"
 }
 
 // ProductionEventInfo contains all production-level information for any GitHub event
 type ProductionEventInfo struct {
-	EventType    string
-	Repository   string
-	Organization string
-	Actor        string
-	Timestamp    string
-	Details      interface{}
-	RawPayload   interface{}
+	EventType    string      `json:"event_type"`
+	Repository   string      `json:"repository,omitempty"`
+	Organization string      `json:"organization,omitempty"`
+	Actor        string      `json:"actor,omitempty"`
+	Timestamp    string      `json:"timestamp"`
+	Details      interface{} `json:"details,omitempty"`
+	RawPayload   interface{} `json:"raw_payload,omitempty"`
 }
@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github_integration/internal/admintoken"
+	"github_integration/internal/jira"
+)
+
+// RequireAdmin protects admin endpoints with the static ADMIN_TOKEN or a time-limited token
+// signed with ADMIN_SIGNING_KEY. Admin endpoints are disabled entirely when neither is configured.
+func (h *WebhookHandler) RequireAdmin(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if h.config.AdminToken == "" && h.config.AdminSigningKey == "" {
+			http.Error(w, "Admin endpoints are disabled", http.StatusForbidden)
+			return
+		}
+
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if err := h.verifyAdminToken(token); err != nil {
+			h.logger.Error(fmt.Sprintf("Unauthorized admin request: %s %s: %v", r.Method, r.URL.Path, err))
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		next(w, r)
+	}
+}
+
+// verifyAdminToken accepts the static admin token or an unexpired signed token
+func (h *WebhookHandler) verifyAdminToken(token string) error {
+	if h.config.AdminToken != "" && subtle.ConstantTimeCompare([]byte(token), []byte(h.config.AdminToken)) == 1 {
+		return nil
+	}
+	if h.config.AdminSigningKey == "" {
+		return errors.New("invalid admin token")
+	}
+	return admintoken.Verify([]byte(h.config.AdminSigningKey), token, time.Now())
+}
+
+// HandleReconcile runs ReconcileRepo for the repository named in the URL
+func (h *WebhookHandler) HandleReconcile(w http.ResponseWriter, r *http.Request) {
+	repo := mux.Vars(r)["repo"]
+
+	scoped, err := h.forRepo(repo)
+	created, updated := 0, 0
+	if err == nil {
+		created, updated, err = scoped.ReconcileRepo(repo)
+	}
+	if err != nil {
+		h.logger.Error(fmt.Sprintf("Reconcile of %s failed: %v", repo, err))
+		writeJSON(w, http.StatusInternalServerError, map[string]interface{}{
+			"repo":  repo,
+			"error": err.Error(),
+		})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"repo":    repo,
+		"created": created,
+		"updated": updated,
+	})
+}
+
+// HandlePRIssue returns the Jira issue linked to the PR named in the URL, with its current status
+func (h *WebhookHandler) HandlePRIssue(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	repo := vars["repo"]
+	prNumber, err := strconv.Atoi(vars["number"])
+	if err != nil || prNumber <= 0 {
+		writeJSON(w, http.StatusBadRequest, map[string]interface{}{"repo": repo, "error": "invalid PR number"})
+		return
+	}
+	if h.jiraClient == nil {
+		writeJSON(w, http.StatusServiceUnavailable, map[string]interface{}{"repo": repo, "pr": prNumber, "error": "jira integration is not configured"})
+		return
+	}
+
+	issue, err := h.jiraClient.FindPRIssue(repo, prNumber)
+	if errors.Is(err, jira.ErrIssueNotFound) {
+		writeJSON(w, http.StatusNotFound, map[string]interface{}{"repo": repo, "pr": prNumber, "error": err.Error()})
+		return
+	}
+	if err != nil {
+		writeJSON(w, http.StatusBadGateway, map[string]interface{}{"repo": repo, "pr": prNumber, "error": err.Error()})
+		return
+	}
+
+	status := ""
+	if issue.Fields != nil && issue.Fields.Status != nil {
+		status = issue.Fields.Status.Name
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"repo":   repo,
+		"pr":     prNumber,
+		"issue":  issue.Key,
+		"status": status,
+		"url":    h.jiraClient.IssueURL(issue.Key),
+	})
+}
+
+// HandleDebugConfig returns the effective configuration with secrets redacted
+func (h *WebhookHandler) HandleDebugConfig(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, h.config.Redacted())
+}
+
+// writeJSON writes v as a JSON response with the given status code
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
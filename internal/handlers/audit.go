@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github_integration/internal/github"
+)
+
+// auditSubjects locates what an audit event acted on, as a path into its payload
+var auditSubjects = map[github.EventType][]string{
+	"team":               {"team", "slug"},
+	"member":             {"member", "login"},
+	"membership":         {"member", "login"},
+	"organization":       {"membership", "user", "login"},
+	"org_block":          {"blocked_user", "login"},
+	"repository_ruleset": {"repository_ruleset", "name"},
+	"custom_property":    {"definition", "property_name"},
+}
+
+// handleAuditEvent records an event the integration takes no action on (team, member,
+// organization, repository_ruleset, ...) as a structured audit log line
+func (h *WebhookHandler) handleAuditEvent(eventType github.EventType) {
+	var payload map[string]interface{}
+	if err := json.Unmarshal(h.result.source.body, &payload); err != nil {
+		h.logger.Info(fmt.Sprintf("Received %s event", eventType))
+		return
+	}
+
+	repoName, _ := payloadPR(payload)
+	sender, _ := payload["sender"].(map[string]interface{})
+	actor, _ := sender["login"].(string)
+	details := map[string]string{}
+	if action, _ := payload["action"].(string); action != "" {
+		details["action"] = action
+	}
+	if subject := payloadString(payload, auditSubjects[eventType]...); subject != "" {
+		details["subject"] = subject
+	}
+
+	info := github.ProductionEventInfo{
+		EventType:    string(eventType),
+		Repository:   repoName,
+		Organization: payloadOrg(payload),
+		Actor:        actor,
+		Timestamp:    time.Now().UTC().Format(time.RFC3339),
+		Details:      details,
+	}
+	line, err := json.Marshal(info)
+	if err != nil {
+		h.logger.Error(fmt.Sprintf("Failed to encode %s audit record: %v", eventType, err))
+		return
+	}
+	h.logger.ProductionLog(string(eventType), string(line))
+}
+
+// payloadString follows path through nested payload objects, returning "" when it doesn't lead to a string
+func payloadString(payload map[string]interface{}, path ...string) string {
+	if len(path) == 0 {
+		return ""
+	}
+	node := payload
+	for _, key := range path[:len(path)-1] {
+		if node, _ = node[key].(map[string]interface{}); node == nil {
+			return ""
+		}
+	}
+	value, _ := node[path[len(path)-1]].(string)
+	return value
+}
@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github_integration/internal/github"
+	"github_integration/internal/jira"
+)
+
+// CatchUpMergedPRs moves the issues of PRs merged within window to the merged status, recovering
+// merge deliveries missed while the service was down. Only recent history is read, so it stays a
+// quick catch-up rather than a backfill. Each repository uses its org's configuration profile.
+func (h *WebhookHandler) CatchUpMergedPRs(ctx context.Context, repos []string, window time.Duration) {
+	if h.jiraClient == nil {
+		return
+	}
+
+	base := h.withContext(ctx)
+	since := time.Now().Add(-window)
+	behind := 0
+	for _, repo := range repos {
+		scoped, err := base.forRepo(repo)
+		if err != nil {
+			h.logger.Error(fmt.Sprintf("Startup catch-up: skipping %s: %v", repo, err))
+			continue
+		}
+		status, ok := scoped.config.TransitionFor(github.EventPullRequest, "merged")
+		if !ok {
+			h.logger.Info(fmt.Sprintf("Startup catch-up skipped for %s - no status is configured for merged PRs", repo))
+			continue
+		}
+
+		prs, err := scoped.githubClient.ListMergedPullRequestsSince(repo, since)
+		if err != nil {
+			h.logger.Error(fmt.Sprintf("Startup catch-up: failed to list merged PRs in %s: %v", repo, err))
+			continue
+		}
+		for _, pr := range prs {
+			if ctx.Err() != nil {
+				return
+			}
+			if scoped.catchUpMergedPR(repo, pr.GetNumber(), status) {
+				behind++
+			}
+		}
+	}
+	h.logger.Info(fmt.Sprintf("Startup catch-up complete: %d PRs merged in the last %s still had open issues", behind, window))
+}
+
+// catchUpMergedPR moves one merged PR's issue to status when it is still in the open status,
+// reporting whether it did. Issues already moved on (to the merged status, Done, ...) are left
+// where they are, and the merge side effects (review comment) are not repeated.
+func (h *WebhookHandler) catchUpMergedPR(repo string, prNumber int, status string) bool {
+	issue, err := h.jiraClient.FindPRIssue(repo, prNumber)
+	if errors.Is(err, jira.ErrIssueNotFound) {
+		return false
+	}
+	if err != nil {
+		h.logger.Error(fmt.Sprintf("Startup catch-up: failed to look up Jira issue for PR #%d in %s: %v", prNumber, repo, err))
+		return false
+	}
+	if issue.Fields == nil || issue.Fields.Status == nil || issue.Fields.Status.Name != h.jiraClient.OpenStatus() {
+		return false
+	}
+
+	h.logger.Info(fmt.Sprintf("Startup catch-up: PR #%d in %s was merged while %s is still open", prNumber, repo, issue.Key))
+	if err := h.jiraClient.MovePRToStatus(repo, prNumber, status); err != nil {
+		h.logger.Error(fmt.Sprintf("Startup catch-up: failed to move PR #%d in %s to %s: %v", prNumber, repo, status, err))
+		return true
+	}
+	h.stats.transitionsDone.Add(1)
+	return true
+}
@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	gogithub "github.com/google/go-github/v56/github"
+
+	"github_integration/internal/github"
+	"github_integration/internal/jira"
+)
+
+// failedConclusions are check conclusions that count as failing CI
+var failedConclusions = map[string]bool{
+	"failure":         true,
+	"timed_out":       true,
+	"cancelled":       true,
+	"action_required": true,
+}
+
+// handleCheckRunEvent reflects a completed check run's commit CI state on its PRs' issues
+func (h *WebhookHandler) handleCheckRunEvent(event *gogithub.CheckRunEvent) {
+	if event.GetAction() != "completed" {
+		return
+	}
+	run := event.GetCheckRun()
+	h.logger.Info(fmt.Sprintf("Check %s on %s in %s completed: %s",
+		run.GetName(), shortSHA(run.GetHeadSHA()), event.GetRepo().GetName(), run.GetConclusion()))
+	h.syncChecks(event.GetRepo().GetName(), run.GetHeadSHA(), run.PullRequests)
+}
+
+// handleCheckSuiteEvent reflects a completed check suite's commit CI state on its PRs' issues
+func (h *WebhookHandler) handleCheckSuiteEvent(event *gogithub.CheckSuiteEvent) {
+	if event.GetAction() != "completed" {
+		return
+	}
+	suite := event.GetCheckSuite()
+	h.logger.Info(fmt.Sprintf("Check suite of %s on %s in %s completed: %s",
+		suite.GetApp().GetName(), shortSHA(suite.GetHeadSHA()), event.GetRepo().GetName(), suite.GetConclusion()))
+	h.syncChecks(event.GetRepo().GetName(), suite.GetHeadSHA(), suite.PullRequests)
+}
+
+// ciState summarises every check on a commit
+type ciState struct {
+	passed  int
+	pending int
+	failed  []string
+}
+
+// outcome is "failure" if any check failed, "success" once all completed without failing, else "pending"
+func (s ciState) outcome() string {
+	switch {
+	case len(s.failed) > 0:
+		return "failure"
+	case s.pending > 0:
+		return "pending"
+	}
+	return "success"
+}
+
+// syncChecks updates the CI comment of each open PR built from sha and applies the transition
+// mapped for the outcome (JIRA_TRANSITIONS check_suite.failure / check_suite.success)
+func (h *WebhookHandler) syncChecks(repoName, sha string, prs []*gogithub.PullRequest) {
+	if h.jiraClient == nil || sha == "" {
+		return
+	}
+	if !h.config.JiraCIStatusComment && len(h.config.JiraTransitions[string(github.EventCheckSuite)]) == 0 {
+		return
+	}
+
+	// Payloads only list PRs from the same repository; look up the rest (e.g. forks) by commit
+	if len(prs) == 0 {
+		var err error
+		if prs, err = h.githubClient.ListPullRequestsWithCommit(repoName, sha); err != nil {
+			h.logger.Error(fmt.Sprintf("Failed to find PRs for commit %s: %v", shortSHA(sha), err))
+			return
+		}
+	}
+	if len(prs) == 0 {
+		return
+	}
+
+	runs, err := h.githubClient.ListCheckRuns(repoName, sha)
+	if err != nil {
+		h.logger.Error(fmt.Sprintf("Failed to list checks of %s: %v", shortSHA(sha), err))
+		h.result.fail(err)
+		return
+	}
+	var state ciState
+	for _, run := range runs {
+		switch {
+		case run.GetStatus() != "completed":
+			state.pending++
+		case failedConclusions[run.GetConclusion()]:
+			state.failed = append(state.failed, run.GetName())
+		default:
+			state.passed++
+		}
+	}
+	sort.Strings(state.failed)
+
+	outcome := state.outcome()
+	status, transition := h.config.TransitionFor(github.EventCheckSuite, outcome)
+	for _, pr := range prs {
+		// Merged or closed PRs keep the status they ended in
+		if pr.GetState() == "closed" {
+			continue
+		}
+		prInfo := jira.PRIssueInfo{PRNumber: pr.GetNumber(), RepoName: repoName, Action: "ci_" + outcome}
+		if h.config.JiraCIStatusComment {
+			h.updateCIComment(prInfo, sha, state)
+		}
+		if transition {
+			h.handlePRTransition(prInfo, status)
+		}
+	}
+}
+
+// updateCIComment keeps the PR issue's single CI comment in line with the commit's checks
+func (h *WebhookHandler) updateCIComment(prInfo jira.PRIssueInfo, sha string, state ciState) {
+	issue, err := h.jiraClient.FindPRIssue(prInfo.RepoName, prInfo.PRNumber)
+	if errors.Is(err, jira.ErrIssueNotFound) {
+		return
+	}
+	if err != nil {
+		h.logger.Error(fmt.Sprintf("Failed to find Jira issue for PR #%d: %v", prInfo.PRNumber, err))
+		return
+	}
+
+	var body string
+	switch state.outcome() {
+	case "failure":
+		body = fmt.Sprintf("(x) *CI failed* on %s: %s failing, %d passed, %d pending",
+			shortSHA(sha), strings.Join(state.failed, ", "), state.passed, state.pending)
+	case "pending":
+		body = fmt.Sprintf("(?) *CI running* on %s: %d passed, %d pending", shortSHA(sha), state.passed, state.pending)
+	default:
+		body = fmt.Sprintf("(/) *CI passing* on %s: all %d checks passed", shortSHA(sha), state.passed)
+	}
+
+	marker := h.jiraStatusMarker(issue.Key, "ci")
+	if err := h.jiraClient.UpsertMarkedComment(issue.Key, marker, body+h.jiraMarker(issue.Key)+marker); err != nil {
+		h.logger.Error(fmt.Sprintf("Failed to update CI comment on %s: %v", issue.Key, err))
+		return
+	}
+	h.logger.Info(fmt.Sprintf("Updated CI status on %s for PR #%d: %s", issue.Key, prInfo.PRNumber, state.outcome()))
+}
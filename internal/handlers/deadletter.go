@@ -0,0 +1,165 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github_integration/internal/deadletter"
+	"github_integration/internal/github"
+	"github_integration/internal/jira"
+)
+
+// delivery is the raw webhook a result came from, kept so failed operations can be replayed
+type delivery struct {
+	id        string
+	endpoint  string
+	eventType github.EventType
+	body      []byte
+	// event is the body parsed into go-github's typed event (nil for types it doesn't know)
+	event interface{}
+	// replay is set when the delivery is being retried from the dead-letter store
+	replay bool
+	// key orders the delivery on the async queue (see orderingKey)
+	key string
+}
+
+// SetDeadLetterStore enables recording failed Jira operations for later replay
+func (h *WebhookHandler) SetDeadLetterStore(store deadletter.Store) {
+	h.deadLetters = store
+}
+
+// deadLetter records a failed Jira operation on the delivery and stores it for replay
+func (h *WebhookHandler) deadLetter(operation, repoName string, prNumber int, err error) {
+	h.result.fail(err)
+
+	if h.deadLetters == nil || h.result == nil || h.result.source.body == nil || h.result.source.replay {
+		return
+	}
+	// A PR without a Jira issue stays that way, so replaying would only fail again
+	if errors.Is(err, jira.ErrIssueNotFound) {
+		return
+	}
+	// Operations cut short by the event deadline are covered by the delivery's own record
+	if errors.Is(h.ctx.Err(), context.DeadlineExceeded) {
+		return
+	}
+
+	source := h.result.source
+	record := deadletter.Record{
+		ID:        h.ids.NewID(),
+		Operation: operation,
+		EventType: string(source.eventType),
+		Endpoint:  source.endpoint,
+		Repo:      repoName,
+		PRNumber:  prNumber,
+		Payload:   json.RawMessage(source.body),
+		Error:     err.Error(),
+		Timestamp: time.Now().UTC(),
+	}
+	if storeErr := h.deadLetters.Add(record); storeErr != nil {
+		h.logger.Error(fmt.Sprintf("Failed to dead-letter %s for %s: %v", operation, repoName, storeErr))
+		return
+	}
+	h.logger.Info(fmt.Sprintf("Dead-lettered %s for %s PR #%d as %s", operation, repoName, prNumber, record.ID))
+}
+
+// dispatcherFor returns the event router used by a webhook endpoint
+func dispatcherFor(endpoint string) (dispatcher, bool) {
+	switch endpoint {
+	case "org":
+		return (*WebhookHandler).dispatchOrgEvent, true
+	case "repo":
+		return (*WebhookHandler).dispatchRepoEvent, true
+	}
+	return nil, false
+}
+
+// HandleListDeadLetters lists failed operations awaiting replay
+func (h *WebhookHandler) HandleListDeadLetters(w http.ResponseWriter, r *http.Request) {
+	if h.deadLetters == nil {
+		http.Error(w, "Dead-letter store is disabled", http.StatusNotFound)
+		return
+	}
+
+	records, err := h.deadLetters.List()
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+		return
+	}
+	if records == nil {
+		records = []deadletter.Record{}
+	}
+	writeJSON(w, http.StatusOK, records)
+}
+
+// HandleRetryDeadLetter replays the delivery behind a dead-lettered operation,
+// removing the record on success and recording the new error otherwise
+func (h *WebhookHandler) HandleRetryDeadLetter(w http.ResponseWriter, r *http.Request) {
+	if h.deadLetters == nil {
+		http.Error(w, "Dead-letter store is disabled", http.StatusNotFound)
+		return
+	}
+
+	id := mux.Vars(r)["id"]
+	record, err := h.deadLetters.Get(id)
+	if errors.Is(err, deadletter.ErrNotFound) {
+		writeJSON(w, http.StatusNotFound, map[string]interface{}{"id": id, "error": err.Error()})
+		return
+	}
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]interface{}{"id": id, "error": err.Error()})
+		return
+	}
+
+	dispatch, ok := dispatcherFor(record.Endpoint)
+	if !ok {
+		writeJSON(w, http.StatusUnprocessableEntity, map[string]interface{}{"id": id, "error": fmt.Sprintf("unknown endpoint %q", record.Endpoint)})
+		return
+	}
+
+	eventType := github.EventType(record.EventType)
+	event, err := parseEvent(eventType, record.Payload)
+	if err != nil {
+		writeJSON(w, http.StatusUnprocessableEntity, map[string]interface{}{"id": id, "error": fmt.Sprintf("invalid stored payload: %v", err)})
+		return
+	}
+	var payload map[string]interface{}
+	if err := json.Unmarshal(record.Payload, &payload); err != nil {
+		writeJSON(w, http.StatusUnprocessableEntity, map[string]interface{}{"id": id, "error": fmt.Sprintf("invalid stored payload: %v", err)})
+		return
+	}
+
+	ctx, span := tracer.Start(r.Context(), "deadletter.retry",
+		trace.WithAttributes(attribute.String("deadletter.id", id), attribute.String("github.event", record.EventType)))
+	defer span.End()
+
+	scoped := h.withContext(ctx)
+	scoped.result.source = delivery{id: id, endpoint: record.Endpoint, eventType: eventType, body: record.Payload, event: event, replay: true}
+	scoped.applyProfile(payloadOrg(payload))
+	scoped.process(dispatch, payload)
+
+	if scoped.result.err != nil {
+		record.Attempts++
+		record.Error = scoped.result.err.Error()
+		if err := h.deadLetters.Update(record); err != nil {
+			h.logger.Error(fmt.Sprintf("Failed to update dead-letter record %s: %v", id, err))
+		}
+		h.logger.Error(fmt.Sprintf("Replay of dead-letter record %s failed: %v", id, scoped.result.err))
+		writeJSON(w, http.StatusBadGateway, record)
+		return
+	}
+
+	if err := h.deadLetters.Remove(id); err != nil {
+		h.logger.Error(fmt.Sprintf("Failed to remove replayed dead-letter record %s: %v", id, err))
+	}
+	h.logger.Info(fmt.Sprintf("Replayed dead-letter record %s (%s on %s)", id, record.Operation, record.Repo))
+	writeJSON(w, http.StatusOK, scoped.result.response(id))
+}
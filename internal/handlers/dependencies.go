@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+
+	"github_integration/internal/jira"
+)
+
+// linkDependencies links a new PR issue to the issues of the PRs it depends on. Dependencies
+// without an issue yet are marked on the new issue and linked when theirs is created.
+func (h *WebhookHandler) linkDependencies(prInfo jira.PRIssueInfo, issueKey string) {
+	for _, dependency := range prInfo.DependsOn {
+		issue, err := h.jiraClient.FindPRIssue(prInfo.RepoName, dependency)
+		if errors.Is(err, jira.ErrIssueNotFound) {
+			if err := h.jiraClient.MarkWaitingDependency(issueKey, dependency); err != nil {
+				h.logger.Error(fmt.Sprintf("Failed to record that %s waits on PR #%d: %v", issueKey, dependency, err))
+				continue
+			}
+			h.logger.Info(fmt.Sprintf("PR #%d depends on PR #%d, which has no Jira issue yet - linking %s once it does",
+				prInfo.PRNumber, dependency, issueKey))
+			continue
+		}
+		if err != nil {
+			h.logger.Error(fmt.Sprintf("Failed to find Jira issue for PR #%d (dependency of PR #%d): %v", dependency, prInfo.PRNumber, err))
+			continue
+		}
+		h.linkDependency(issueKey, issue.Key)
+	}
+}
+
+// linkWaitingDependents links the issues that were waiting for this PR's issue to exist
+func (h *WebhookHandler) linkWaitingDependents(prInfo jira.PRIssueInfo, issueKey string) {
+	dependents, err := h.jiraClient.TakeWaitingDependents(prInfo.RepoName, prInfo.PRNumber)
+	if err != nil {
+		h.logger.Error(fmt.Sprintf("Failed to find issues waiting on PR #%d: %v", prInfo.PRNumber, err))
+	}
+	for _, dependentKey := range dependents {
+		h.linkDependency(dependentKey, issueKey)
+	}
+}
+
+// linkDependency links dependentKey to dependencyKey (the inward issue) with the dependency link type
+func (h *WebhookHandler) linkDependency(dependentKey, dependencyKey string) {
+	if err := h.jiraClient.LinkIssues(dependentKey, dependencyKey, h.config.JiraDependencyLinkType); err != nil {
+		h.logger.Error(fmt.Sprintf("Failed to link %s to its dependency %s: %v", dependentKey, dependencyKey, err))
+		return
+	}
+	h.logger.Info(fmt.Sprintf("Linked %s to its dependency %s (%s)", dependentKey, dependencyKey, h.config.JiraDependencyLinkType))
+}
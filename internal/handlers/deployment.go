@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"fmt"
+
+	gogithub "github.com/google/go-github/v56/github"
+
+	"github_integration/internal/github"
+	"github_integration/internal/jira"
+)
+
+// handleDeploymentStatusEvent moves the issues of merged PRs contained in a deployed SHA to the
+// status configured for the deployment state (e.g. deployment_status.success → Done)
+func (h *WebhookHandler) handleDeploymentStatusEvent(event *gogithub.DeploymentStatusEvent) {
+	state := event.GetDeploymentStatus().GetState()
+	sha := event.GetDeployment().GetSHA()
+	environment := event.GetDeployment().GetEnvironment()
+	repoName := event.GetRepo().GetName()
+
+	h.logger.Info(fmt.Sprintf("Deployment of %s to %s in %s: %s", shortSHA(sha), environment, repoName, state))
+
+	if h.jiraClient == nil || sha == "" {
+		return
+	}
+	status, ok := h.config.TransitionFor(github.EventDeploymentStatus, state)
+	if !ok {
+		return
+	}
+
+	prs, err := h.githubClient.ListPullRequestsWithCommit(repoName, sha)
+	if err != nil {
+		h.logger.Error(fmt.Sprintf("Failed to find PRs deployed in %s: %v", shortSHA(sha), err))
+		return
+	}
+
+	for _, pr := range prs {
+		// Open PRs can also contain the commit; only merged work counts as deployed
+		if pr.MergedAt == nil {
+			continue
+		}
+		h.handlePRTransition(jira.PRIssueInfo{
+			PRNumber: pr.GetNumber(),
+			PRTitle:  pr.GetTitle(),
+			RepoName: repoName,
+			Action:   "deployed",
+		}, status)
+	}
+}
@@ -0,0 +1,32 @@
+package handlers
+
+import (
+	"path"
+	"sort"
+	"strings"
+)
+
+// extensionLabels returns the labels JIRA_EXTENSION_LABELS assigns to the changed files' extensions,
+// sorted and without duplicates. Extensions match case-insensitively, with or without the dot.
+func (h *WebhookHandler) extensionLabels(files []string) []string {
+	if len(h.config.JiraExtensionLabels) == 0 {
+		return nil
+	}
+
+	byExtension := make(map[string]string, len(h.config.JiraExtensionLabels))
+	for ext, label := range h.config.JiraExtensionLabels {
+		byExtension["."+strings.TrimPrefix(strings.ToLower(ext), ".")] = label
+	}
+
+	seen := make(map[string]bool)
+	var labels []string
+	for _, file := range files {
+		label, ok := byExtension[strings.ToLower(path.Ext(file))]
+		if ok && label != "" && !seen[label] {
+			seen[label] = true
+			labels = append(labels, label)
+		}
+	}
+	sort.Strings(labels)
+	return labels
+}
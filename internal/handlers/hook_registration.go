@@ -0,0 +1,177 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github_integration/internal/github"
+	"github_integration/internal/notifier"
+)
+
+// pendingHooks tracks repositories whose webhook registration failed, for later retry. With a
+// file set the set survives restarts.
+type pendingHooks struct {
+	mu    sync.Mutex
+	repos map[string]int // repo name → failed attempts
+	path  string
+}
+
+func newPendingHooks() *pendingHooks {
+	return &pendingHooks{repos: make(map[string]int)}
+}
+
+// load reads a previously saved set from path and keeps saving every change there
+func (p *pendingHooks) load(path string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &p.repos); err != nil {
+			return fmt.Errorf("invalid pending webhook file %s: %w", path, err)
+		}
+	}
+	p.path = path
+	return nil
+}
+
+// save writes the set to its file (replacing it atomically); the caller holds mu
+func (p *pendingHooks) save() error {
+	if p.path == "" {
+		return nil
+	}
+	data, err := json.Marshal(p.repos)
+	if err != nil {
+		return err
+	}
+	tmp := p.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("failed to save pending webhooks: %w", err)
+	}
+	if err := os.Rename(tmp, p.path); err != nil {
+		return fmt.Errorf("failed to save pending webhooks: %w", err)
+	}
+	return nil
+}
+
+// add records a failed attempt for repo and returns how many have failed so far
+func (p *pendingHooks) add(repo string) (int, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.repos[repo]++
+	return p.repos[repo], p.save()
+}
+
+func (p *pendingHooks) remove(repo string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, ok := p.repos[repo]; !ok {
+		return nil
+	}
+	delete(p.repos, repo)
+	return p.save()
+}
+
+// list returns the pending repositories in name order
+func (p *pendingHooks) list() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	repos := make([]string, 0, len(p.repos))
+	for repo := range p.repos {
+		repos = append(repos, repo)
+	}
+	sort.Strings(repos)
+	return repos
+}
+
+// SetPendingHooksFile keeps failed webhook registrations in path so their retries survive restarts
+func (h *WebhookHandler) SetPendingHooksFile(path string) error {
+	return h.pendingHooks.load(path)
+}
+
+// SetNotifier sets where operational alerts (e.g. failed webhook registration) are sent
+func (h *WebhookHandler) SetNotifier(alerts notifier.Notifier) {
+	h.alerts = alerts
+}
+
+// registerRepoWebhook adds the integration's webhook to a repository. The GitHub client retries
+// transient errors with backoff (the create is safe to repeat, as a duplicate is reported as
+// ErrHookExists); if it still fails the repo is queued for background retries, and an alert is
+// raised once WEBHOOK_RETRY_MAX_ATTEMPTS attempts have failed.
+func (h *WebhookHandler) registerRepoWebhook(repoName string) {
+	if h.config.WebhookPublicURL == "" {
+		h.logger.Info(fmt.Sprintf("WEBHOOK_PUBLIC_URL not set - not adding a webhook to %s", repoName))
+		return
+	}
+
+	events := h.config.WebhookEventsFor(repoName)
+	err := h.githubClient.CreateRepoWebhook(repoName, h.config.WebhookPublicURL, h.config.GitHubWebhookSecret, events)
+	if err == nil {
+		h.forgetPendingHook(repoName)
+		if len(events) > 0 {
+			h.logger.Info(fmt.Sprintf("Successfully added webhook to new repo %s for events %s", repoName, strings.Join(events, ", ")))
+		} else {
+			h.logger.Info(fmt.Sprintf("Successfully added webhook to new repo: %s", repoName))
+		}
+		return
+	}
+	// The goal is a registered webhook, so one that is already there counts as success
+	if errors.Is(err, github.ErrHookExists) {
+		h.forgetPendingHook(repoName)
+		h.logger.Info(fmt.Sprintf("Webhook already registered on repo %s - nothing to do", repoName))
+		return
+	}
+
+	attempts, saveErr := h.pendingHooks.add(repoName)
+	if saveErr != nil {
+		h.logger.Error(fmt.Sprintf("WARNING: %v", saveErr))
+	}
+	maxAttempts := h.config.WebhookRetryMaxAttempts
+	h.logger.Error(fmt.Sprintf("Failed to add webhook to new repo %s (attempt %d of %d): %v", repoName, attempts, maxAttempts, err))
+	if attempts < maxAttempts {
+		return
+	}
+
+	// Out of attempts: stop retrying and tell someone, as only a person can fix it now
+	h.forgetPendingHook(repoName)
+	if h.alerts != nil {
+		if alertErr := h.alerts.Notify("Webhook registration failed",
+			fmt.Sprintf("Could not add the webhook to %s after %d attempts: %v. Add it by hand.", repoName, attempts, err)); alertErr != nil {
+			h.logger.Error(fmt.Sprintf("Failed to send webhook registration alert: %v", alertErr))
+		}
+	}
+}
+
+// forgetPendingHook stops retrying a repository's webhook registration
+func (h *WebhookHandler) forgetPendingHook(repoName string) {
+	if err := h.pendingHooks.remove(repoName); err != nil {
+		h.logger.Error(fmt.Sprintf("WARNING: %v", err))
+	}
+}
+
+// RetryPendingWebhooks periodically re-attempts webhook registrations that failed, until ctx ends
+func (h *WebhookHandler) RetryPendingWebhooks(ctx context.Context) {
+	ticker := time.NewTicker(h.config.WebhookRetryInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for _, repo := range h.pendingHooks.list() {
+				h.registerRepoWebhook(repo)
+			}
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"fmt"
+	"strings"
+)
+
+// noReplyDomain hosts GitHub's anonymized commit addresses, e.g. 12345+octocat@users.noreply.github.com
+const noReplyDomain = "@users.noreply.github.com"
+
+// noReplyLogin returns the login hidden in a GitHub noreply address
+func noReplyLogin(email string) (string, bool) {
+	local, ok := strings.CutSuffix(strings.ToLower(email), noReplyDomain)
+	if !ok {
+		return "", false
+	}
+	if _, login, found := strings.Cut(local, "+"); found {
+		return login, login != ""
+	}
+	return local, local != ""
+}
+
+// jiraAccountFor resolves the best available identity to a mapped Jira account, trying in turn
+// the commit email, the GitHub login and (with JIRA_USER_EMAIL_LOOKUP) the user's public email.
+// A noreply commit address stands in for its login. Either argument may be empty.
+func (h *WebhookHandler) jiraAccountFor(email, login string) (string, bool) {
+	if login == "" {
+		login, _ = noReplyLogin(email)
+	}
+	if _, anonymized := noReplyLogin(email); email != "" && !anonymized {
+		if accountID, ok := h.config.JiraAccountFor(email); ok {
+			return accountID, true
+		}
+	}
+	if login == "" {
+		return "", false
+	}
+	if accountID, ok := h.config.JiraAccountFor(login); ok {
+		return accountID, true
+	}
+
+	if !h.config.JiraUserEmailLookup {
+		return "", false
+	}
+	publicEmail, err := h.githubClient.GetUserEmail(login)
+	if err != nil {
+		h.logger.Error(fmt.Sprintf("WARNING: could not look up the public email of %s: %v", login, err))
+		return "", false
+	}
+	if publicEmail == "" || strings.EqualFold(publicEmail, email) {
+		return "", false
+	}
+	return h.config.JiraAccountFor(publicEmail)
+}
@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github_integration/internal/metrics"
+)
+
+var (
+	webhooksInFlight = metrics.NewGauge("webhook_requests_in_flight", "Webhook requests currently being handled")
+	webhooksShed     = metrics.NewCounter("webhook_requests_shed_total", "Webhook requests rejected with 503 because WEBHOOK_MAX_IN_FLIGHT was reached")
+)
+
+// LimitInFlight returns a wrapper that lets at most limit webhook requests run at once.
+// Every route wrapped by the same returned func shares one limit; requests beyond it get
+// 503 with Retry-After. GitHub doesn't retry failed deliveries by itself, and shed ones never
+// reach the dead-letter store, so operators must redeliver them from the hook's recent
+// deliveries (the 503s are counted in webhook_requests_shed_total). A limit of 0 or less
+// disables it.
+func LimitInFlight(limit int, retryAfter time.Duration) func(http.HandlerFunc) http.HandlerFunc {
+	if limit <= 0 {
+		return func(next http.HandlerFunc) http.HandlerFunc { return next }
+	}
+
+	slots := make(chan struct{}, limit)
+	seconds := strconv.Itoa(int((retryAfter + time.Second - 1) / time.Second))
+	return func(next http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case slots <- struct{}{}:
+			default:
+				webhooksShed.Inc()
+				w.Header().Set("Retry-After", seconds)
+				http.Error(w, "Too many webhook deliveries in flight", http.StatusServiceUnavailable)
+				return
+			}
+			webhooksInFlight.Inc()
+			defer func() {
+				webhooksInFlight.Dec()
+				<-slots
+			}()
+			next(w, r)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"fmt"
+
+	gogithub "github.com/google/go-github/v56/github"
+)
+
+// handleInstallationEvent processes GitHub App installs and uninstalls
+func (h *WebhookHandler) handleInstallationEvent(event *gogithub.InstallationEvent) {
+	if !h.config.AppAuth() {
+		h.logger.Info("Ignoring installation event - not running in GitHub App auth mode")
+		return
+	}
+
+	action := event.GetAction()
+	repos := repoNames(event.Repositories)
+	account := event.GetInstallation().GetAccount().GetLogin()
+
+	switch action {
+	case "created":
+		h.logger.Info(fmt.Sprintf("GitHub App installed with access to %d repositories", len(repos)))
+		go h.detached().onboardRepos(account, repos)
+	case "deleted":
+		h.logger.Info(fmt.Sprintf("GitHub App uninstalled - %d repositories no longer tracked", len(repos)))
+		h.offboardRepos(repos)
+	default:
+		h.logger.Info(fmt.Sprintf("Installation event: %s", action))
+	}
+}
+
+// handleInstallationRepositoriesEvent processes repositories being granted to or revoked from the app
+func (h *WebhookHandler) handleInstallationRepositoriesEvent(event *gogithub.InstallationRepositoriesEvent) {
+	if !h.config.AppAuth() {
+		h.logger.Info("Ignoring installation_repositories event - not running in GitHub App auth mode")
+		return
+	}
+
+	added := repoNames(event.RepositoriesAdded)
+	removed := repoNames(event.RepositoriesRemoved)
+	h.logger.Info(fmt.Sprintf("GitHub App repository access changed: %d added, %d removed", len(added), len(removed)))
+
+	if len(added) > 0 {
+		go h.detached().onboardRepos(event.GetInstallation().GetAccount().GetLogin(), added)
+	}
+	h.offboardRepos(removed)
+}
+
+// onboardRepos reconciles open PRs for repositories newly accessible in account's installation,
+// under the account's configuration profile. It runs in the background because a large backfill
+// would outlive the webhook request.
+func (h *WebhookHandler) onboardRepos(account string, repos []string) {
+	if h.jiraClient == nil {
+		return
+	}
+	scoped := h.forOrg(account)
+	for _, repo := range repos {
+		if _, _, err := scoped.ReconcileRepo(repo); err != nil {
+			h.logger.Error(fmt.Sprintf("Failed to onboard repo %s: %v", repo, err))
+		}
+	}
+}
+
+// offboardRepos stops tracking repositories the app can no longer access: pending webhook
+// registrations, cached details and any transferred-owner override are dropped
+func (h *WebhookHandler) offboardRepos(repos []string) {
+	for _, repo := range repos {
+		h.forgetPendingHook(repo)
+		h.githubClient.InvalidateRepository(repo)
+		if err := h.githubClient.SetRepoOwner(repo, ""); err != nil {
+			h.logger.Error(fmt.Sprintf("Failed to forget the owner of %s: %v", repo, err))
+		}
+		h.logger.Info(fmt.Sprintf("Repository %s removed from GitHub App - no further events will be processed", repo))
+	}
+}
+
+// repoNames extracts repository names from an installation payload list
+func repoNames(repos []*gogithub.Repository) []string {
+	var names []string
+	for _, repo := range repos {
+		if name := repo.GetName(); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
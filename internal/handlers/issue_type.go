@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github_integration/internal/jira"
+)
+
+// conventionalType matches a conventional commit header, e.g. "feat(api)!: add search"
+var conventionalType = regexp.MustCompile(`^\s*([A-Za-z]+)(\([^)]*\))?!?:`)
+
+// parseConventionalType returns the lower-cased type of a conventional commit header
+func parseConventionalType(text string) (string, bool) {
+	m := conventionalType.FindStringSubmatch(text)
+	if m == nil {
+		return "", false
+	}
+	return strings.ToLower(m[1]), true
+}
+
+// issueTypeFor returns the issue type override for a PR, or "" to use the default type: first
+// from the conventional commit type of its title or first commit (JIRA_COMMIT_TYPE_ISSUE_TYPES),
+// then from its repo's topics (JIRA_TOPIC_ISSUE_TYPES). Types the project doesn't have are ignored
+// with a warning.
+func (h *WebhookHandler) issueTypeFor(client *jira.Client, prInfo jira.PRIssueInfo) string {
+	if issueType, source := h.commitTypeIssueType(prInfo); issueType != "" {
+		if resolved, ok := h.resolveIssueType(client, issueType, source); ok {
+			return resolved
+		}
+	}
+	if issueType, source := h.topicIssueType(prInfo.RepoName); issueType != "" {
+		if resolved, ok := h.resolveIssueType(client, issueType, source); ok {
+			return resolved
+		}
+	}
+	return ""
+}
+
+// commitTypeIssueType maps the PR title's conventional commit type, falling back to the first commit's
+func (h *WebhookHandler) commitTypeIssueType(prInfo jira.PRIssueInfo) (string, string) {
+	if len(h.config.JiraCommitTypeIssueTypes) == 0 {
+		return "", ""
+	}
+
+	commitType, ok := parseConventionalType(prInfo.PRTitle)
+	source := "title of PR #" + fmt.Sprint(prInfo.PRNumber)
+	if !ok {
+		message, err := h.githubClient.GetPRFirstCommitMessage(prInfo.RepoName, prInfo.PRNumber)
+		if err != nil {
+			h.logger.Error(fmt.Sprintf("WARNING: could not read the first commit of PR #%d: %v", prInfo.PRNumber, err))
+			return "", ""
+		}
+		if commitType, ok = parseConventionalType(message); !ok {
+			return "", ""
+		}
+		source = "first commit of PR #" + fmt.Sprint(prInfo.PRNumber)
+	}
+
+	issueType := h.config.JiraCommitTypeIssueTypes[commitType]
+	return issueType, fmt.Sprintf("%q type of the %s", commitType, source)
+}
+
+// topicIssueType maps the first of the repo's topics with a configured issue type
+func (h *WebhookHandler) topicIssueType(repoName string) (string, string) {
+	if len(h.config.JiraTopicIssueTypes) == 0 {
+		return "", ""
+	}
+
+	topics, err := h.githubClient.RepoTopics(repoName)
+	if err != nil {
+		h.logger.Error(fmt.Sprintf("WARNING: could not read topics of %s - using the default issue type: %v", repoName, err))
+		return "", ""
+	}
+	for _, topic := range topics {
+		if issueType := h.config.JiraTopicIssueTypes[topic]; issueType != "" {
+			return issueType, fmt.Sprintf("topic %q of %s", topic, repoName)
+		}
+	}
+	return "", ""
+}
+
+// resolveIssueType checks that the project has issueType, returning its spelling in the project
+func (h *WebhookHandler) resolveIssueType(client *jira.Client, issueType, source string) (string, bool) {
+	resolved, exists, err := client.ResolveIssueType(issueType)
+	if err != nil {
+		h.logger.Error(fmt.Sprintf("WARNING: could not check issue type %q: %v", issueType, err))
+		return "", false
+	}
+	if !exists {
+		h.logger.Error(fmt.Sprintf("WARNING: issue type %q (from %s) does not exist in project %s",
+			issueType, source, client.Options().ProjectKey))
+		return "", false
+	}
+	h.logger.Info(fmt.Sprintf("Using issue type %s (from %s)", resolved, source))
+	return resolved, true
+}
@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"fmt"
+	"time"
+
+	gogithub "github.com/google/go-github/v56/github"
+
+	"github_integration/internal/metrics"
+)
+
+var (
+	deliveryLatencyLast  = metrics.NewGauge("webhook_delivery_latency_milliseconds", "Delay between the GitHub event time and processing, for the latest delivery")
+	deliveryLatencySum   = metrics.NewCounter("webhook_delivery_latency_milliseconds_sum", "Total delay between GitHub event times and processing")
+	deliveryLatencyCount = metrics.NewCounter("webhook_delivery_latency_count", "Deliveries with a measurable event time")
+)
+
+// eventTime returns when GitHub says the event happened, for event types that carry a timestamp
+func eventTime(event interface{}) (time.Time, bool) {
+	var ts *gogithub.Timestamp
+	switch event := event.(type) {
+	case *gogithub.PullRequestEvent:
+		ts = event.GetPullRequest().UpdatedAt
+	case *gogithub.PushEvent:
+		ts = event.GetHeadCommit().Timestamp
+	case *gogithub.IssueCommentEvent:
+		ts = event.GetComment().CreatedAt
+	case *gogithub.DeploymentStatusEvent:
+		ts = event.GetDeploymentStatus().CreatedAt
+	}
+	if ts == nil || ts.IsZero() {
+		return time.Time{}, false
+	}
+	return ts.Time, true
+}
+
+// recordDeliveryLatency measures how long after the event GitHub delivered it and it reached
+// processing, which surfaces both GitHub delivery delays and our own queue backlog
+func (h *WebhookHandler) recordDeliveryLatency(now time.Time) {
+	source := h.result.source
+	if source.replay {
+		return
+	}
+	at, ok := eventTime(source.event)
+	if !ok {
+		return
+	}
+
+	// Clock skew between GitHub and this host can put the event "in the future"
+	latency := now.Sub(at)
+	if latency < 0 {
+		latency = 0
+	}
+
+	deliveryLatencyLast.Set(latency.Milliseconds())
+	deliveryLatencySum.Add(latency.Milliseconds())
+	deliveryLatencyCount.Inc()
+	h.logger.Info(h.tagged(fmt.Sprintf("%s delivery latency: %s", source.eventType, latency.Round(time.Millisecond))))
+}
@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// GitHub hides HTML comments in rendered markdown; Jira wiki markup renders {anchor} macros invisibly
+var (
+	githubMarkerPattern = regexp.MustCompile(`<!-- ([\w.-]+):([A-Za-z0-9_-]+) -->`)
+	jiraMarkerPattern   = regexp.MustCompile(`\{anchor:([\w.-]+?)-([A-Z][A-Z0-9_]*-\d+)\}`)
+)
+
+// githubMarker is the invisible tag appended to comments the integration posts on GitHub
+func (h *WebhookHandler) githubMarker(issueKey string) string {
+	return fmt.Sprintf("<!-- %s:%s -->", h.config.CommentMarker, issueKey)
+}
+
+// jiraMarker is the invisible tag appended to comments the integration posts on Jira
+func (h *WebhookHandler) jiraMarker(issueKey string) string {
+	return JiraCommentMarker(h.config.CommentMarker, issueKey)
+}
+
+// JiraCommentMarker is jiraMarker for Jira comments posted outside a delivery (e.g. the daily summary)
+func JiraCommentMarker(marker, issueKey string) string {
+	return fmt.Sprintf("{anchor:%s-%s}", marker, issueKey)
+}
+
+// jiraStatusMarker tags a living status comment (e.g. purpose "ci") so it can be found and updated
+// in place; the comment also carries jiraMarker so it is still recognised as the integration's own
+func (h *WebhookHandler) jiraStatusMarker(issueKey, purpose string) string {
+	return fmt.Sprintf("{anchor:%s-%s-%s}", h.config.CommentMarker, issueKey, purpose)
+}
+
+// hasMarker reports whether a GitHub or Jira comment body was posted by the integration
+func (h *WebhookHandler) hasMarker(body string) bool {
+	_, ok := h.extractMarker(body)
+	return ok
+}
+
+// extractMarker returns the Jira issue key tagged in a comment the integration posted
+func (h *WebhookHandler) extractMarker(body string) (string, bool) {
+	for _, pattern := range []*regexp.Regexp{githubMarkerPattern, jiraMarkerPattern} {
+		for _, match := range pattern.FindAllStringSubmatch(body, -1) {
+			if match[1] == h.config.CommentMarker {
+				return match[2], true
+			}
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github_integration/internal/utils"
+)
+
+// WithWriteTimeout extends the write deadline for a single route.
+//
+// The server-wide WriteTimeout bounds every response, but a synchronous webhook
+// keeps the connection open while it waits on GitHub and Jira. If that work
+// outlives the server timeout, the client receives a truncated response even
+// though processing finished. Webhook routes therefore get their own, longer
+// deadline (WEBHOOK_WRITE_TIMEOUT). In async mode (WEBHOOK_ASYNC=true)
+// deliveries are acknowledged before any API calls, so the server default is
+// normally sufficient.
+func WithWriteTimeout(timeout time.Duration, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Ignore the error: wrapped writers that can't set deadlines keep the server default
+		http.NewResponseController(w).SetWriteDeadline(time.Now().Add(timeout))
+		next(w, r)
+	}
+}
+
+// statusRecorder captures the status code written by the wrapped handler
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (s *statusRecorder) WriteHeader(status int) {
+	s.status = status
+	s.ResponseWriter.WriteHeader(status)
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController (used by WithWriteTimeout)
+func (s *statusRecorder) Unwrap() http.ResponseWriter {
+	return s.ResponseWriter
+}
+
+// AccessLog logs method, path, status, latency and GitHub delivery headers for every request
+func AccessLog(logger *utils.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+			next.ServeHTTP(rec, r)
+
+			logger.Info(fmt.Sprintf("HTTP method=%s path=%s status=%d duration=%s event=%s delivery=%s",
+				r.Method, r.URL.Path, rec.status, time.Since(start).Round(time.Millisecond),
+				headerOrDash(r, "X-GitHub-Event"), headerOrDash(r, "X-GitHub-Delivery")))
+		})
+	}
+}
+
+// headerOrDash returns a header value, or "-" when absent, keeping log fields aligned
+func headerOrDash(r *http.Request, name string) string {
+	if value := r.Header.Get(name); value != "" {
+		return value
+	}
+	return "-"
+}
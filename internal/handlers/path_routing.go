@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"fmt"
+	"strings"
+)
+
+// defaultProject is the repository's own project (JIRA_PROJECT_FROM_REPO) or else JIRA_PROJECT_KEY
+func (h *WebhookHandler) defaultProject(repoName string) string {
+	if rules := h.jiraClient.Options().ProjectKeyRules; rules != nil {
+		if _, err := rules.Derive(repoName); err != nil {
+			h.logger.Error(fmt.Sprintf("WARNING: %v - using project %s", err, h.jiraClient.Options().ProjectKey))
+		}
+	}
+	return h.jiraClient.ProjectFor(repoName)
+}
+
+// projectsForFiles picks the Jira projects a PR's issue is created in from its changed files.
+// Each file maps to the project of its longest matching path prefix; files matching no prefix
+// don't vote. A PR touching several projects goes to the repository's default project unless
+// JIRA_PATH_ROUTE_ALL is set, in which case it gets an issue in each.
+func (h *WebhookHandler) projectsForFiles(repoName string, files []string) []string {
+	defaultProject := []string{h.defaultProject(repoName)}
+	if len(h.config.JiraPathProjects) == 0 {
+		return defaultProject
+	}
+
+	seen := make(map[string]bool)
+	var projects []string
+	for _, file := range files {
+		project, longest := "", -1
+		for prefix, key := range h.config.JiraPathProjects {
+			if strings.HasPrefix(file, prefix) && len(prefix) > longest {
+				project, longest = key, len(prefix)
+			}
+		}
+		if project != "" && !seen[project] {
+			seen[project] = true
+			projects = append(projects, project)
+		}
+	}
+
+	switch {
+	case len(projects) == 0:
+		return defaultProject
+	case len(projects) == 1 || h.config.JiraPathRouteAll:
+		return projects
+	default:
+		h.logger.Info(h.tagged("PR touches several routed projects (" + strings.Join(projects, ", ") + ") - using the default project"))
+		return defaultProject
+	}
+}
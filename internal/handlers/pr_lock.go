@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"fmt"
+
+	gogithub "github.com/google/go-github/v56/github"
+
+	"github_integration/internal/jira"
+)
+
+// handlePRLock mirrors a PR being locked or unlocked onto its issue: a comment saying who did it
+// (and why, for locks), then a move to the locked status or back to the unlocked one
+func (h *WebhookHandler) handlePRLock(prInfo jira.PRIssueInfo, event *gogithub.PullRequestEvent) {
+	if !h.config.JiraSyncLocks {
+		h.logger.Info(fmt.Sprintf("PR #%d in %s %s - lock syncing is off", prInfo.PRNumber, prInfo.RepoName, prInfo.Action))
+		return
+	}
+
+	status := h.config.JiraLockedStatus
+	body := fmt.Sprintf("PR #%d was locked by %s", prInfo.PRNumber, event.GetSender().GetLogin())
+	if reason := event.GetPullRequest().GetActiveLockReason(); reason != "" {
+		body += fmt.Sprintf(" (reason: %s)", reason)
+	}
+	if prInfo.Action == "unlocked" {
+		status = h.config.JiraUnlockedStatus
+		if status == "" {
+			status = h.jiraClient.Options().OpenStatus
+		}
+		body = fmt.Sprintf("PR #%d was unlocked by %s", prInfo.PRNumber, event.GetSender().GetLogin())
+	}
+
+	issue, err := h.jiraClient.FindPRIssue(prInfo.RepoName, prInfo.PRNumber)
+	if err != nil {
+		h.logger.Error(fmt.Sprintf("Failed to find Jira issue for PR #%d: %v", prInfo.PRNumber, err))
+		return
+	}
+	if err := h.jiraClient.AddComment(issue.Key, body+h.jiraMarker(issue.Key)); err != nil {
+		h.logger.Error(fmt.Sprintf("Failed to comment %s PR on %s: %v", prInfo.Action, issue.Key, err))
+	}
+	h.handlePRTransition(prInfo, status)
+}
@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"fmt"
+
+	"github_integration/internal/github"
+)
+
+// payloadOrg returns the org (or user) owning the delivery's repository
+func payloadOrg(payload map[string]interface{}) string {
+	if org, ok := payload["organization"].(map[string]interface{}); ok {
+		if login, _ := org["login"].(string); login != "" {
+			return login
+		}
+	}
+	repo, _ := payload["repository"].(map[string]interface{})
+	owner, _ := repo["owner"].(map[string]interface{})
+	login, _ := owner["login"].(string)
+	return login
+}
+
+// applyProfile switches a per-delivery handler copy to the org's configuration profile,
+// re-scoping the Jira client to the profile's project, labels and statuses
+func (h *WebhookHandler) applyProfile(org string) {
+	cfg := h.config.ForOrg(org)
+	if cfg == h.config {
+		return
+	}
+	h.config = cfg
+
+	if h.jiraClient != nil {
+		opts := h.jiraClient.Options()
+		opts.ProjectKey = cfg.JiraProjectKey
+		opts.LabelPrefix = cfg.JiraLabelPrefix
+		opts.DefaultEpic = cfg.JiraDefaultEpic
+		opts.OpenStatus, _ = cfg.TransitionFor(github.EventPullRequest, "opened")
+		h.jiraClient = h.jiraClient.WithOptions(opts)
+	}
+}
+
+// forOrg returns a handler copy using org's configuration profile, for work that doesn't start
+// from a delivery (reconcile, onboarding, catch-up)
+func (h *WebhookHandler) forOrg(org string) *WebhookHandler {
+	scoped := *h
+	scoped.applyProfile(org)
+	return &scoped
+}
+
+// forRepo is forOrg for the account owning repoName
+func (h *WebhookHandler) forRepo(repoName string) (*WebhookHandler, error) {
+	if len(h.config.Profiles) == 0 {
+		return h, nil
+	}
+	repo, err := h.githubClient.GetRepositoryDetails(repoName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve the owner of %s for its configuration profile: %w", repoName, err)
+	}
+	return h.forOrg(repo.GetOwner().GetLogin()), nil
+}
@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	gogithub "github.com/google/go-github/v56/github"
+
+	"github_integration/internal/github"
+)
+
+// nullSHA is the before/after SHA GitHub sends for branch creation and deletion
+const nullSHA = "0000000000000000000000000000000000000000"
+
+// describePushRange summarises a large push from one compare call instead of one call per commit.
+// It reports false when the per-commit path should be used instead: small pushes, new branches,
+// and force pushes whose before and after SHAs don't connect.
+func (h *WebhookHandler) describePushRange(block *strings.Builder, repoName, branch, before, after string, commits []*gogithub.HeadCommit) ([]github.CommitInfo, bool) {
+	minCommits := h.config.PushCompareMinCommits
+	if minCommits == 0 || len(commits) < minCommits || before == "" || before == nullSHA || after == "" || after == nullSHA {
+		return nil, false
+	}
+
+	compare, err := h.githubClient.GetCompare(repoName, before, after)
+	if err != nil {
+		h.logger.Error(h.tagged(fmt.Sprintf("Failed to compare push range, fetching commits individually: %v", err)))
+		return nil, false
+	}
+	if !compare.Connected() {
+		h.logger.Info(h.tagged(fmt.Sprintf("Push to %s rewrote history (%s) - fetching commits individually", branch, compare.Status)))
+		return nil, false
+	}
+
+	fmt.Fprintf(block, "PUSH RANGE %s...%s: %d commits, %d files, +%d/-%d\n",
+		shortSHA(before), shortSHA(after), compare.TotalCommits, len(compare.Files), compare.Additions, compare.Deletions)
+
+	// Per-commit stats aren't part of a comparison, so commits carry only their payload details
+	var processed []github.CommitInfo
+	for i, commit := range commits {
+		commitSHA := commit.GetID()
+		message := commit.GetMessage()
+		authorName := commit.GetAuthor().GetName()
+		authorEmail := commit.GetAuthor().GetEmail()
+
+		fmt.Fprintf(block, "COMMIT #%d: %s %s <%s>: %s\n", i+1, shortSHA(commitSHA), authorName, authorEmail, message)
+		processed = append(processed, github.CommitInfo{
+			SHA:         commitSHA,
+			Message:     message,
+			Author:      authorName,
+			AuthorEmail: authorEmail,
+			Date:        time.Now().Format(time.RFC3339),
+			Repository:  repoName,
+			Branch:      branch,
+		})
+	}
+
+	block.WriteString("  RANGE DIFF CONTENT:\n")
+	block.WriteString(strings.Repeat("-", 60) + "\n")
+	block.WriteString(compare.DiffContent + "\n")
+	block.WriteString(strings.Repeat("-", 60) + "\n")
+
+	return processed, true
+}
@@ -0,0 +1,38 @@
+package handlers
+
+import "fmt"
+
+// handleForcePush reports a push that rewrote history instead of diffing commits that may no
+// longer be reachable, optionally noting the rewrite on the branch's Jira issue
+func (h *WebhookHandler) handleForcePush(repoName, branch, pusher, before, after string) {
+	h.logger.Error(h.tagged(fmt.Sprintf("WARNING: force push to %s in %s by %s (%s -> %s) - skipping per-commit diffs",
+		branch, repoName, pusher, shortSHA(before), shortSHA(after))))
+
+	if h.jiraClient == nil || !h.config.JiraCommentForcePush {
+		return
+	}
+
+	pr, err := h.githubClient.FindOpenPRForBranch(repoName, branch)
+	if err != nil {
+		h.logger.Error(fmt.Sprintf("Failed to find PR for branch %s: %v", branch, err))
+		return
+	}
+	if pr == nil {
+		return
+	}
+
+	issue, err := h.jiraClient.FindPRIssue(repoName, pr.GetNumber())
+	if err != nil {
+		h.logger.Error(fmt.Sprintf("Failed to find Jira issue for PR #%d: %v", pr.GetNumber(), err))
+		return
+	}
+
+	body := fmt.Sprintf("(!) *History rewritten:* %s force-pushed %s ({{%s}} → {{%s}}). The PR diff reviewers saw may have changed.",
+		pusher, branch, shortSHA(before), shortSHA(after))
+	if err := h.jiraClient.AddComment(issue.Key, body+h.jiraMarker(issue.Key)); err != nil {
+		h.logger.Error(fmt.Sprintf("Failed to comment force push on %s: %v", issue.Key, err))
+		h.deadLetter("force_push_comment", repoName, pr.GetNumber(), err)
+		return
+	}
+	h.logger.Info(fmt.Sprintf("Noted force push to %s on %s", branch, issue.Key))
+}
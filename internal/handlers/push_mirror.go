@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"fmt"
+	"strings"
+
+	"github_integration/internal/github"
+)
+
+// maxJiraCommentLength is Jira's limit on a single comment body
+const maxJiraCommentLength = 32767
+
+// mirrorPushToJira posts one comment listing every pushed commit on the Jira issue of the branch's open PR
+func (h *WebhookHandler) mirrorPushToJira(repoName, branch, pusher string, commits []github.CommitInfo) {
+	pr, err := h.githubClient.FindOpenPRForBranch(repoName, branch)
+	if err != nil {
+		h.logger.Error(fmt.Sprintf("Failed to find PR for branch %s: %v", branch, err))
+		return
+	}
+	if pr == nil {
+		return // No open PR for this branch, nothing to mirror
+	}
+
+	issue, err := h.jiraClient.FindPRIssue(repoName, pr.GetNumber())
+	if err != nil {
+		h.logger.Error(fmt.Sprintf("Failed to find Jira issue for PR #%d: %v", pr.GetNumber(), err))
+		h.deadLetter("mirror_push", repoName, pr.GetNumber(), err)
+		return
+	}
+
+	if err := h.jiraClient.AddComment(issue.Key, formatPushComment(branch, pusher, commits)+h.jiraMarker(issue.Key)); err != nil {
+		h.logger.Error(fmt.Sprintf("Failed to comment push on %s: %v", issue.Key, err))
+		h.deadLetter("mirror_push", repoName, pr.GetNumber(), err)
+		return
+	}
+	h.logger.Info(fmt.Sprintf("Mirrored %d commits on %s to %s", len(commits), branch, issue.Key))
+}
+
+// formatPushComment renders commits as one Jira comment, truncating to the comment size limit
+func formatPushComment(branch, pusher string, commits []github.CommitInfo) string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("*Push to %s by %s* (%d commits)\n", branch, pusher, len(commits)))
+
+	for i, commit := range commits {
+		subject, _, _ := strings.Cut(commit.Message, "\n")
+		if commit.IsMerge {
+			subject = "_(merge)_ " + subject
+		}
+		line := fmt.Sprintf("* {{%s}} %s (+%d/-%d)\n", shortSHA(commit.SHA), subject, commit.Additions, commit.Deletions)
+		if commit.FilesChanged == 0 && commit.Additions == 0 && commit.Deletions == 0 {
+			// Commits summarised from a compare call have no per-commit stats
+			line = fmt.Sprintf("* {{%s}} %s\n", shortSHA(commit.SHA), subject)
+		}
+
+		// Leave room for the overflow note
+		if b.Len()+len(line) > maxJiraCommentLength-100 {
+			b.WriteString(fmt.Sprintf("_...and %d more commits not shown_\n", len(commits)-i))
+			break
+		}
+		b.WriteString(line)
+	}
+
+	return b.String()
+}
+
+// shortSHA abbreviates a commit SHA for display
+func shortSHA(sha string) string {
+	if len(sha) > 8 {
+		return sha[:8]
+	}
+	return sha
+}
@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"fmt"
+	"strings"
+
+	gogithub "github.com/google/go-github/v56/github"
+)
+
+// Ref kinds returned by parseRef
+const (
+	refBranch = "branch"
+	refTag    = "tag"
+	refOther  = "other"
+)
+
+// parseRef splits a fully-qualified git ref into its kind and short name, e.g.
+// refs/heads/main -> (branch, main) and refs/tags/v1.2.0 -> (tag, v1.2.0). Other refs
+// (e.g. refs/notes/commits) are returned whole with kind "other".
+func parseRef(ref string) (kind, name string) {
+	if name, ok := strings.CutPrefix(ref, "refs/heads/"); ok {
+		return refBranch, name
+	}
+	if name, ok := strings.CutPrefix(ref, "refs/tags/"); ok {
+		return refTag, name
+	}
+	return refOther, ref
+}
+
+// handleTagPush logs a tag being created, moved or deleted; tags carry no new commits to describe
+func (h *WebhookHandler) handleTagPush(event *gogithub.PushEvent, tag string) {
+	repoName := event.GetRepo().GetName()
+	pusherName := event.GetPusher().GetName()
+
+	switch {
+	case event.GetDeleted():
+		h.logger.Info(fmt.Sprintf("Tag %s deleted in %s by %s", tag, repoName, pusherName))
+	case event.GetCreated():
+		h.logger.Info(fmt.Sprintf("Tag %s created in %s by %s at %s", tag, repoName, pusherName, shortSHA(event.GetAfter())))
+	default:
+		h.logger.Info(fmt.Sprintf("Tag %s moved in %s by %s: %s -> %s", tag, repoName, pusherName,
+			shortSHA(event.GetBefore()), shortSHA(event.GetAfter())))
+	}
+}
@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+
+	"github_integration/internal/github"
+	"github_integration/internal/jira"
+)
+
+// ReconcileRepo ensures every open PR in a repository has a Jira issue. Missing issues are
+// created the way the webhook path creates them; issues left in the merged or closed status
+// (e.g. a reopen that was never delivered) are moved back to the open status. Other statuses are
+// ones the integration set on purpose for open PRs (review requested, CI failure...) and are kept.
+func (h *WebhookHandler) ReconcileRepo(repo string) (created, updated int, err error) {
+	if h.jiraClient == nil {
+		return 0, 0, fmt.Errorf("jira integration is not configured")
+	}
+
+	prs, err := h.githubClient.ListOpenPullRequests(repo)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	h.logger.Info(fmt.Sprintf("Reconciling %d open PRs in %s against Jira", len(prs), repo))
+
+	openStatus := h.jiraClient.OpenStatus()
+	terminal := make(map[string]bool)
+	for _, action := range []string{"merged", "closed"} {
+		if status, ok := h.config.TransitionFor(github.EventPullRequest, action); ok && status != openStatus {
+			terminal[status] = true
+		}
+	}
+
+	for _, pr := range prs {
+		prNumber := pr.GetNumber()
+
+		issue, findErr := h.jiraClient.FindPRIssue(repo, prNumber)
+		if findErr == nil {
+			current := ""
+			if issue.Fields != nil && issue.Fields.Status != nil {
+				current = issue.Fields.Status.Name
+			}
+			if !terminal[current] {
+				continue
+			}
+			if !h.jiraClient.Options().StateMachine.Allows(current, openStatus) {
+				h.logger.Info(fmt.Sprintf("Reconcile: leaving %s (PR #%d) in %s - JIRA_STATE_MACHINE forbids moving it to %s",
+					issue.Key, prNumber, current, openStatus))
+				continue
+			}
+			if moveErr := h.jiraClient.MovePRToOpen(repo, prNumber); moveErr != nil {
+				h.logger.Error(fmt.Sprintf("Reconcile: failed to move %s (PR #%d) to %s: %v", issue.Key, prNumber, openStatus, moveErr))
+				continue
+			}
+			updated++
+			continue
+		}
+		if !errors.Is(findErr, jira.ErrIssueNotFound) {
+			h.logger.Error(fmt.Sprintf("Reconcile: failed to look up Jira issue for PR #%d: %v", prNumber, findErr))
+			continue
+		}
+
+		details, detailsErr := h.githubClient.GetPullRequestDetails(repo, prNumber)
+		if detailsErr != nil {
+			h.logger.Error(fmt.Sprintf("Reconcile: failed to get PR #%d details: %v", prNumber, detailsErr))
+			continue
+		}
+		if reason := h.skipReason(details); reason != "" {
+			h.logger.Info(fmt.Sprintf("Reconcile: skipping PR #%d: %s", prNumber, reason))
+			continue
+		}
+
+		// Each PR gets its own result so a created issue can be told apart from a failure
+		scoped := h.withContext(h.ctx)
+		scoped.handlePROpened(buildPRIssueInfo(repo, details, "opened"))
+		if scoped.result.issueKey == "" {
+			h.logger.Error(fmt.Sprintf("Reconcile: failed to create Jira issue for PR #%d: %v", prNumber, scoped.result.err))
+			continue
+		}
+		h.logger.Info(fmt.Sprintf("Reconcile: created Jira issue %s for PR #%d", scoped.result.issueKey, prNumber))
+		created++
+	}
+
+	h.logger.Info(fmt.Sprintf("Reconcile of %s complete: %d created, %d updated", repo, created, updated))
+	return created, updated, nil
+}
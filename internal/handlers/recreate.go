@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"fmt"
+
+	"github_integration/internal/jira"
+)
+
+// recreateMissingIssue files a fresh issue for a merged PR whose original issue was deleted
+// in Jira, creating it directly in the merged status so the merge isn't lost. PRs that never
+// qualified for an issue (skipReason) or were detached with /jira skip are left alone.
+func (h *WebhookHandler) recreateMissingIssue(prInfo jira.PRIssueInfo, status string) {
+	details, err := h.githubClient.GetPullRequestDetails(prInfo.RepoName, prInfo.PRNumber)
+	if err != nil {
+		h.logger.Error(h.tagged(fmt.Sprintf("Failed to get PR #%d details before recreating its Jira issue: %v", prInfo.PRNumber, err)))
+		h.deadLetter("recreate_issue", prInfo.RepoName, prInfo.PRNumber, err)
+		return
+	}
+	if reason := h.skipReason(details); reason != "" {
+		h.logger.Info(h.tagged(fmt.Sprintf("Not recreating a Jira issue for merged PR #%d in %s: %s", prInfo.PRNumber, prInfo.RepoName, reason)))
+		return
+	}
+	detached, err := h.jiraClient.IsPRDetached(prInfo.RepoName, prInfo.PRNumber)
+	if err != nil {
+		h.logger.Error(h.tagged(fmt.Sprintf("Failed to check whether PR #%d was detached from Jira: %v", prInfo.PRNumber, err)))
+		h.deadLetter("recreate_issue", prInfo.RepoName, prInfo.PRNumber, err)
+		return
+	}
+	if detached {
+		h.logger.Info(h.tagged(fmt.Sprintf("Not recreating a Jira issue for merged PR #%d in %s: detached with /jira skip", prInfo.PRNumber, prInfo.RepoName)))
+		return
+	}
+
+	h.logger.Error(h.tagged(fmt.Sprintf("WARNING: Jira issue for PR #%d in %s is missing (deleted in Jira?) - recreating it in %s",
+		prInfo.PRNumber, prInfo.RepoName, status)))
+
+	// Callers such as catch-up only know the PR's number, so rebuild the full info from GitHub
+	prInfo = buildPRIssueInfo(prInfo.RepoName, details, prInfo.Action)
+
+	opts := h.jiraClient.Options()
+	opts.OpenStatus = status
+	scoped := *h
+	scoped.jiraClient = h.jiraClient.WithOptions(opts)
+
+	for _, project := range scoped.projectsForFiles(prInfo.RepoName, prInfo.FilesChanged) {
+		scoped.createPRIssue(prInfo, project)
+	}
+}
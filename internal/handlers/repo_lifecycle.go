@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"fmt"
+	"strings"
+
+	gogithub "github.com/google/go-github/v56/github"
+)
+
+// handleRepositoryRenamed moves state kept under a repository's old name to its new one:
+// cached GitHub details/owner and the repo label on its Jira issues
+func (h *WebhookHandler) handleRepositoryRenamed(event *gogithub.RepositoryEvent) {
+	newName := event.GetRepo().GetName()
+	oldName := event.GetChanges().GetRepo().GetName().GetFrom()
+	if oldName == "" || oldName == newName {
+		h.logger.Error(fmt.Sprintf("Rename event for %s carries no previous name - nothing to update", newName))
+		return
+	}
+	h.logger.Info(fmt.Sprintf("Repository %s renamed to %s", oldName, newName))
+
+	if err := h.githubClient.RenameRepo(oldName, newName); err != nil {
+		h.logger.Error(fmt.Sprintf("Failed to persist the owner of renamed repository %s: %v", newName, err))
+	}
+	if stale := h.config.RepoSettingsNaming(oldName); len(stale) > 0 {
+		h.logger.Error(fmt.Sprintf("WARNING: %s still configure repository %s under its old name - update them to %s",
+			strings.Join(stale, ", "), oldName, newName))
+	}
+
+	if h.jiraClient == nil {
+		return
+	}
+	keys, err := h.jiraClient.RenameRepo(oldName, newName)
+	if len(keys) > 0 {
+		h.logger.Info(fmt.Sprintf("Relabelled %d Jira issues from %s to %s: %s", len(keys), oldName, newName, strings.Join(keys, ", ")))
+	}
+	if err != nil {
+		h.logger.Error(fmt.Sprintf("Failed to relabel Jira issues of renamed repository %s: %v", oldName, err))
+		h.result.fail(err)
+	}
+}
+
+// handleRepositoryArchived closes the open Jira issues of a retired repository's PRs
+func (h *WebhookHandler) handleRepositoryArchived(event *gogithub.RepositoryEvent) {
+	repoName := event.GetRepo().GetName()
+	if h.jiraClient == nil {
+		h.logger.Info(fmt.Sprintf("Repository %s archived", repoName))
+		return
+	}
+
+	closed, err := h.jiraClient.CloseAllOpenForRepo(repoName)
+	h.logger.Info(fmt.Sprintf("Repository %s archived - closed %d open Jira issues", repoName, closed))
+	if err != nil {
+		h.logger.Error(fmt.Sprintf("Failed to close some Jira issues of archived repository %s: %v", repoName, err))
+		h.result.fail(err)
+	}
+}
+
+// handleRepositoryTransferred points API calls for a transferred repository at its new owner
+func (h *WebhookHandler) handleRepositoryTransferred(event *gogithub.RepositoryEvent) {
+	repoName := event.GetRepo().GetName()
+	newOwner := event.GetRepo().GetOwner().GetLogin()
+
+	from := event.GetChanges().GetOwner().GetOwnerInfo()
+	oldOwner := from.GetOrg().GetLogin()
+	if oldOwner == "" {
+		oldOwner = from.GetUser().GetLogin()
+	}
+
+	h.logger.Info(fmt.Sprintf("Repository %s transferred from %s to %s", repoName, oldOwner, newOwner))
+	h.githubClient.InvalidateRepository(repoName)
+	if err := h.githubClient.SetRepoOwner(repoName, newOwner); err != nil {
+		h.logger.Error(fmt.Sprintf("Failed to persist the new owner of %s: %v", repoName, err))
+	}
+}
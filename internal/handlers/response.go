@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/gorilla/mux"
+
+	"github_integration/internal/events"
+)
+
+// Webhook processing states reported in WebhookResponse.Status
+const (
+	StatusOK       = "ok"
+	StatusAccepted = "accepted"
+	StatusError    = "error"
+)
+
+// maxTrackedDeliveries bounds how many async outcomes /webhook/status remembers
+const maxTrackedDeliveries = 1000
+
+// WebhookResponse is the stable JSON body returned by the webhook endpoints and /webhook/status/{id}
+type WebhookResponse struct {
+	Status        string `json:"status"`
+	Issue         string `json:"issue,omitempty"`
+	CorrelationID string `json:"correlation_id,omitempty"`
+	Error         string `json:"error,omitempty"`
+}
+
+// eventResult collects the outcome of processing a single delivery
+type eventResult struct {
+	issueKey string
+	err      error
+	source   delivery
+}
+
+// setIssue records the Jira issue created for the delivery (no-op outside a delivery)
+func (r *eventResult) setIssue(key string) {
+	if r != nil {
+		r.issueKey = key
+	}
+}
+
+// fail records a processing error for the delivery (no-op outside a delivery)
+func (r *eventResult) fail(err error) {
+	if r != nil {
+		r.err = err
+	}
+}
+
+// response converts the outcome into the public response schema
+func (r *eventResult) response(correlationID string) WebhookResponse {
+	resp := WebhookResponse{Status: StatusOK, Issue: r.issueKey, CorrelationID: correlationID}
+	if r.err != nil {
+		resp.Status = StatusError
+		resp.Error = r.err.Error()
+	}
+	return resp
+}
+
+// statusStore remembers recent async outcomes by correlation ID, evicting the oldest first
+type statusStore struct {
+	mu      sync.Mutex
+	entries map[string]WebhookResponse
+	order   []string
+}
+
+func newStatusStore() *statusStore {
+	return &statusStore{entries: make(map[string]WebhookResponse)}
+}
+
+// set records or replaces the outcome for a correlation ID
+func (s *statusStore) set(id string, resp WebhookResponse) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.entries[id]; !exists {
+		s.order = append(s.order, id)
+		if len(s.order) > maxTrackedDeliveries {
+			delete(s.entries, s.order[0])
+			s.order = s.order[1:]
+		}
+	}
+	s.entries[id] = resp
+}
+
+// get returns the outcome recorded for a correlation ID
+func (s *statusStore) get(id string) (WebhookResponse, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	resp, ok := s.entries[id]
+	return resp, ok
+}
+
+// recordStatus is the status-buffer subscriber: it remembers each delivery's outcome for /webhook/status
+func (h *WebhookHandler) recordStatus(event events.ProcessedEvent) {
+	resp := WebhookResponse{Status: StatusOK, Issue: event.Issue, CorrelationID: event.ID}
+	if event.Err != nil {
+		resp.Status = StatusError
+		resp.Error = event.Err.Error()
+	}
+	h.statuses.set(event.ID, resp)
+}
+
+// HandleWebhookStatus reports the outcome of an asynchronously processed delivery
+func (h *WebhookHandler) HandleWebhookStatus(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	resp, ok := h.statuses.get(id)
+	if !ok {
+		writeJSON(w, http.StatusNotFound, WebhookResponse{
+			Status:        StatusError,
+			CorrelationID: id,
+			Error:         "unknown correlation id",
+		})
+		return
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// correlationID identifies a delivery, preferring GitHub's delivery GUID
+func (h *WebhookHandler) correlationID(r *http.Request) string {
+	if id := r.Header.Get("X-GitHub-Delivery"); id != "" {
+		return id
+	}
+	return h.ids.NewID()
+}
+
+// tagged prefixes a log message with the delivery's correlation ID so one event's output can be grepped
+func (h *WebhookHandler) tagged(message string) string {
+	if h.result == nil || h.result.source.id == "" {
+		return message
+	}
+	return "[" + h.result.source.id + "] " + message
+}
+
+// logBlock writes a multi-line block in a single logger call, each line tagged with the delivery ID,
+// so blocks from concurrent deliveries never interleave
+func (h *WebhookHandler) logBlock(block string) {
+	lines := strings.Split(strings.TrimSuffix(block, "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = h.tagged(line)
+	}
+	h.logger.Info(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github_integration/internal/utils"
+)
+
+// syncBuffer is a bytes.Buffer safe for concurrent writers
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestLogBlockDoesNotInterleave(t *testing.T) {
+	const deliveries, linesPerBlock = 50, 5
+
+	var out syncBuffer
+	base := &WebhookHandler{logger: utils.NewLoggerWithWriters(&out, &out)}
+
+	var wg sync.WaitGroup
+	for d := 0; d < deliveries; d++ {
+		wg.Add(1)
+		go func(d int) {
+			defer wg.Done()
+			h := *base
+			h.result = &eventResult{source: delivery{id: fmt.Sprintf("d-%d", d)}}
+
+			var block strings.Builder
+			for i := 0; i < linesPerBlock; i++ {
+				fmt.Fprintf(&block, "line %d\n", i)
+			}
+			h.logBlock(block.String())
+		}(d)
+	}
+	wg.Wait()
+
+	// Every block must appear as one contiguous run of its own tagged lines
+	runs := make(map[string]int)
+	var current string
+	for _, line := range strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n") {
+		start := strings.Index(line, "[")
+		end := strings.Index(line, "]")
+		if start < 0 || end < start {
+			t.Fatalf("untagged log line %q", line)
+		}
+		tag := line[start+1 : end]
+		if tag != current {
+			if _, seen := runs[tag]; seen {
+				t.Fatalf("block %s was interleaved with another:\n%s", tag, out.String())
+			}
+			current = tag
+		}
+		runs[tag]++
+	}
+
+	if len(runs) != deliveries {
+		t.Fatalf("got %d blocks, want %d", len(runs), deliveries)
+	}
+	for tag, n := range runs {
+		if n != linesPerBlock {
+			t.Errorf("block %s has %d lines, want %d", tag, n, linesPerBlock)
+		}
+	}
+}
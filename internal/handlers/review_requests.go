@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"fmt"
+
+	gogithub "github.com/google/go-github/v56/github"
+
+	"github_integration/internal/github"
+	"github_integration/internal/jira"
+)
+
+// handleReviewRequest notes a requested (or withdrawn) reviewer on the PR's issue and applies
+// the transition configured for the action, if any (e.g. review_requested -> In Review)
+func (h *WebhookHandler) handleReviewRequest(prInfo jira.PRIssueInfo, event *gogithub.PullRequestEvent) {
+	if h.config.JiraCommentReviewRequests {
+		h.commentReviewRequest(prInfo, requestedReviewer(event), event.GetSender().GetLogin())
+	}
+	if status, ok := h.config.TransitionFor(github.EventPullRequest, prInfo.Action); ok {
+		h.handlePRTransition(prInfo, status)
+	}
+}
+
+// requestedReviewer names the user or team in a review request event
+func requestedReviewer(event *gogithub.PullRequestEvent) string {
+	if login := event.GetRequestedReviewer().GetLogin(); login != "" {
+		return login
+	}
+	if team := event.GetRequestedTeam().GetSlug(); team != "" {
+		return "team " + team
+	}
+	return "unknown reviewer"
+}
+
+// commentReviewRequest records on the Jira issue who was asked to review the PR
+func (h *WebhookHandler) commentReviewRequest(prInfo jira.PRIssueInfo, reviewer, requester string) {
+	issue, err := h.jiraClient.FindPRIssue(prInfo.RepoName, prInfo.PRNumber)
+	if err != nil {
+		h.logger.Error(fmt.Sprintf("Failed to find Jira issue for PR #%d: %v", prInfo.PRNumber, err))
+		return
+	}
+
+	body := fmt.Sprintf("Review of PR #%d requested from *%s* by %s", prInfo.PRNumber, reviewer, requester)
+	if prInfo.Action == "review_request_removed" {
+		body = fmt.Sprintf("Review request for *%s* on PR #%d withdrawn by %s", reviewer, prInfo.PRNumber, requester)
+	}
+	if err := h.jiraClient.AddComment(issue.Key, body+h.jiraMarker(issue.Key)); err != nil {
+		h.logger.Error(fmt.Sprintf("Failed to comment review request on %s: %v", issue.Key, err))
+		h.deadLetter("review_request_comment", prInfo.RepoName, prInfo.PRNumber, err)
+		return
+	}
+	h.logger.Info(fmt.Sprintf("Noted review request for %s on %s (PR #%d)", reviewer, issue.Key, prInfo.PRNumber))
+}
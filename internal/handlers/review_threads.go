@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"fmt"
+
+	"github_integration/internal/github"
+	"github_integration/internal/jira"
+)
+
+// commentMergeReviewStatus records on the Jira issue how many review threads were unresolved at merge,
+// flagging merges that left threads open
+func (h *WebhookHandler) commentMergeReviewStatus(prInfo jira.PRIssueInfo) {
+	summary, err := h.githubClient.GetReviewThreadSummary(prInfo.RepoName, prInfo.PRNumber)
+	if err != nil {
+		h.logger.Error(fmt.Sprintf("Failed to get review threads for PR #%d: %v", prInfo.PRNumber, err))
+		return
+	}
+
+	issue, err := h.jiraClient.FindPRIssue(prInfo.RepoName, prInfo.PRNumber)
+	if err != nil {
+		h.logger.Error(fmt.Sprintf("Failed to find Jira issue for PR #%d: %v", prInfo.PRNumber, err))
+		return
+	}
+
+	if err := h.jiraClient.AddComment(issue.Key, formatMergeReviewComment(prInfo.PRNumber, summary)+h.jiraMarker(issue.Key)); err != nil {
+		h.logger.Error(fmt.Sprintf("Failed to comment merge review status on %s: %v", issue.Key, err))
+		h.deadLetter("merge_comment", prInfo.RepoName, prInfo.PRNumber, err)
+		return
+	}
+
+	if summary.Unresolved > 0 {
+		h.logger.Info(fmt.Sprintf("PR #%d in %s merged with %d unresolved review threads (%s)",
+			prInfo.PRNumber, prInfo.RepoName, summary.Unresolved, issue.Key))
+	}
+}
+
+// formatMergeReviewComment renders the merge comment, using Jira's warning icon when threads were left unresolved
+func formatMergeReviewComment(prNumber int, summary github.ReviewThreadSummary) string {
+	if summary.Unresolved > 0 {
+		return fmt.Sprintf("(!) *PR #%d merged with unresolved review threads:* %d of %d unresolved at merge time",
+			prNumber, summary.Unresolved, summary.Total)
+	}
+	return fmt.Sprintf("(/) PR #%d merged with all review threads resolved (%d threads)", prNumber, summary.Total)
+}
@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+
+	gogithub "github.com/google/go-github/v56/github"
+)
+
+var (
+	errMissingSignature = errors.New("missing webhook signature header")
+	errSHA1NotAllowed   = errors.New("only an SHA-1 signature was sent and ALLOW_SHA1_SIGNATURES is off")
+)
+
+// deliverySignature picks the signature header to verify. SHA-256 is always preferred;
+// SHA-1 (X-Hub-Signature) is only accepted when allowSHA1 is set.
+func deliverySignature(header http.Header, allowSHA1 bool) (signature, algorithm string, err error) {
+	if sig := header.Get(gogithub.SHA256SignatureHeader); sig != "" {
+		return sig, "sha256", nil
+	}
+	if sig := header.Get(gogithub.SHA1SignatureHeader); sig != "" {
+		if !allowSHA1 {
+			return "", "", errSHA1NotAllowed
+		}
+		return sig, "sha1", nil
+	}
+	return "", "", errMissingSignature
+}
+
+// readPayload reads a delivery body (capped at WebhookMaxBodyBytes) through go-github's
+// ValidatePayloadFromBody, verifying its signature when a webhook secret is configured.
+// It writes the error response itself and returns false if the request must be rejected.
+func (h *WebhookHandler) readPayload(w http.ResponseWriter, r *http.Request) ([]byte, bool) {
+	deliveryID := gogithub.DeliveryID(r)
+	r.Body = http.MaxBytesReader(w, r.Body, h.config.WebhookMaxBodyBytes)
+	defer r.Body.Close()
+
+	var signature, algorithm string
+	if h.config.GitHubWebhookSecret != "" {
+		var err error
+		if signature, algorithm, err = deliverySignature(r.Header, h.config.AllowSHA1Signatures); err != nil {
+			h.logger.Error(fmt.Sprintf("Rejected webhook delivery %s: %v", deliveryID, err))
+			http.Error(w, "Invalid signature", http.StatusUnauthorized)
+			return nil, false
+		}
+	}
+
+	// GitHub always sends a content type; treat a bare request as JSON
+	contentType := "application/json"
+	if header := r.Header.Get("Content-Type"); header != "" {
+		parsed, _, err := mime.ParseMediaType(header)
+		if err != nil || (parsed != "application/json" && parsed != "application/x-www-form-urlencoded") {
+			h.logger.Error(fmt.Sprintf("Rejected webhook delivery %s with content type %q", deliveryID, header))
+			http.Error(w, "Unsupported content type", http.StatusUnsupportedMediaType)
+			return nil, false
+		}
+		contentType = parsed
+	}
+
+	payload, err := gogithub.ValidatePayloadFromBody(contentType, r.Body, signature, []byte(h.config.GitHubWebhookSecret))
+	if err != nil {
+		var maxErr *http.MaxBytesError
+		switch {
+		case errors.As(err, &maxErr):
+			h.logger.Error(fmt.Sprintf("Rejected webhook payload larger than %d bytes", maxErr.Limit))
+			http.Error(w, "Payload too large", http.StatusRequestEntityTooLarge)
+		case signature != "":
+			h.logger.Error(fmt.Sprintf("Rejected webhook delivery %s: %v", deliveryID, err))
+			http.Error(w, "Invalid signature", http.StatusUnauthorized)
+		default:
+			h.logger.Error(fmt.Sprintf("Failed to read request body: %v", err))
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+		}
+		return nil, false
+	}
+
+	if algorithm != "" {
+		h.logger.Info(fmt.Sprintf("Webhook delivery %s verified with %s", deliveryID, algorithm))
+	}
+	return payload, true
+}
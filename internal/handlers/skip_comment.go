@@ -0,0 +1,31 @@
+package handlers
+
+import (
+	"fmt"
+
+	"github_integration/internal/jira"
+)
+
+// commentSkipReason tells the PR author why no Jira issue was created, posting at most once per PR
+func (h *WebhookHandler) commentSkipReason(prInfo jira.PRIssueInfo, reason string) {
+	if !h.config.JiraCommentSkipReason {
+		return
+	}
+
+	marker := h.githubMarker("skipped")
+	exists, err := h.githubClient.PRCommentExists(prInfo.RepoName, prInfo.PRNumber, marker)
+	if err != nil {
+		h.logger.Error(fmt.Sprintf("Failed to check existing comments on PR #%d: %v", prInfo.PRNumber, err))
+		return
+	}
+	if exists {
+		return
+	}
+
+	body := fmt.Sprintf("No Jira issue was created for this PR: %s.\n\n%s", reason, marker)
+	if err := h.githubClient.CommentOnPR(prInfo.RepoName, prInfo.PRNumber, body); err != nil {
+		h.logger.Error(fmt.Sprintf("Failed to explain skipped Jira issue on PR #%d: %v", prInfo.PRNumber, err))
+		return
+	}
+	h.logger.Info(fmt.Sprintf("Explained skipped Jira issue on PR #%d in %s", prInfo.PRNumber, prInfo.RepoName))
+}
@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"fmt"
+	"strings"
+
+	gogithub "github.com/google/go-github/v56/github"
+)
+
+// slashCommandPrefix starts a command line in a PR comment, e.g. "/jira status In Review"
+const slashCommandPrefix = "/jira"
+
+// slashCommandUsage is replied when a comment holds an unrecognised command
+const slashCommandUsage = "Usage: `/jira status <Jira status>` moves the linked issue, `/jira skip` stops tracking this PR in Jira."
+
+// slashCommand is one parsed /jira command
+type slashCommand struct {
+	name string
+	arg  string
+}
+
+// parseSlashCommand returns the first /jira command in a comment body
+func parseSlashCommand(body string) (slashCommand, bool) {
+	for _, line := range strings.Split(body, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 || fields[0] != slashCommandPrefix {
+			continue
+		}
+		if len(fields) == 1 {
+			return slashCommand{}, true
+		}
+		return slashCommand{name: strings.ToLower(fields[1]), arg: strings.Join(fields[2:], " ")}, true
+	}
+	return slashCommand{}, false
+}
+
+// handleIssueCommentEvent runs /jira slash commands posted by collaborators on a PR
+func (h *WebhookHandler) handleIssueCommentEvent(event *gogithub.IssueCommentEvent) {
+	if h.jiraClient == nil || !h.config.JiraSlashCommands {
+		return
+	}
+	if event.GetAction() != "created" || !event.GetIssue().IsPullRequest() {
+		return
+	}
+
+	body := event.GetComment().GetBody()
+	// Never act on the integration's own replies
+	if h.hasMarker(body) {
+		return
+	}
+	cmd, ok := parseSlashCommand(body)
+	if !ok {
+		return
+	}
+
+	repoName := event.GetRepo().GetName()
+	prNumber := event.GetIssue().GetNumber()
+	login := event.GetComment().GetUser().GetLogin()
+
+	allowed, err := h.githubClient.IsCollaborator(repoName, login)
+	if err != nil {
+		h.logger.Error(fmt.Sprintf("Failed to check whether %s may run /jira commands: %v", login, err))
+		h.result.fail(err)
+		return
+	}
+	if !allowed {
+		h.logger.Info(fmt.Sprintf("Ignoring /jira command from non-collaborator %s on PR #%d in %s", login, prNumber, repoName))
+		return
+	}
+
+	h.logger.Info(fmt.Sprintf("Running /jira %s %s from %s on PR #%d in %s", cmd.name, cmd.arg, login, prNumber, repoName))
+
+	var reply string
+	switch {
+	case cmd.name == "status" && cmd.arg != "":
+		reply = h.runStatusCommand(repoName, prNumber, cmd.arg)
+	case cmd.name == "skip" && cmd.arg == "":
+		reply = h.runSkipCommand(repoName, prNumber)
+	default:
+		reply = slashCommandUsage
+	}
+
+	reply = fmt.Sprintf("@%s %s\n\n%s", login, reply, h.githubMarker("command"))
+	if err := h.githubClient.CommentOnPR(repoName, prNumber, reply); err != nil {
+		h.logger.Error(fmt.Sprintf("Failed to reply to /jira command on PR #%d: %v", prNumber, err))
+	}
+}
+
+// runStatusCommand moves the PR's issues to status, returning the reply text
+func (h *WebhookHandler) runStatusCommand(repoName string, prNumber int, status string) string {
+	issues, err := h.jiraClient.FindPRIssues(repoName, prNumber)
+	if err != nil {
+		h.result.fail(err)
+		return fmt.Sprintf("could not find the Jira issue for this PR: %v", err)
+	}
+	if err := h.jiraClient.MovePRToStatus(repoName, prNumber, status); err != nil {
+		h.result.fail(err)
+		return fmt.Sprintf("could not move the Jira issue to %q: %v", status, err)
+	}
+
+	keys := make([]string, len(issues))
+	for i, issue := range issues {
+		keys[i] = issue.Key
+	}
+	h.result.setIssue(keys[0])
+	return fmt.Sprintf("moved %s to %q.", strings.Join(keys, ", "), status)
+}
+
+// runSkipCommand detaches the PR's issues from the integration, returning the reply text
+func (h *WebhookHandler) runSkipCommand(repoName string, prNumber int) string {
+	keys, err := h.jiraClient.DetachPRIssues(repoName, prNumber)
+	if err != nil {
+		h.result.fail(err)
+		return fmt.Sprintf("could not detach the Jira issue: %v", err)
+	}
+	return fmt.Sprintf("detached %s - this PR is no longer tracked in Jira.", strings.Join(keys, ", "))
+}
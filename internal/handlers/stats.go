@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github_integration/internal/events"
+	"github_integration/internal/github"
+	"github_integration/internal/jira"
+	"github_integration/internal/metrics"
+)
+
+// serviceStats are the in-memory counters served as JSON by /stats
+type serviceStats struct {
+	started         time.Time
+	eventsReceived  *metrics.CounterSet
+	errors          *metrics.CounterSet
+	errorCategories *metrics.CounterSet
+	issuesCreated   atomic.Int64
+	transitionsDone atomic.Int64
+}
+
+func newServiceStats() *serviceStats {
+	return &serviceStats{
+		started:         time.Now(),
+		eventsReceived:  metrics.NewCounterSet(),
+		errors:          metrics.NewCounterSet(),
+		errorCategories: metrics.NewCounterSet(),
+	}
+}
+
+// recordOutcome counts failed deliveries by event type and error category (a bus subscriber)
+func (s *serviceStats) recordOutcome(event events.ProcessedEvent) {
+	if event.Err != nil {
+		s.errors.Inc(event.EventType)
+		s.errorCategories.Inc(errorCategory(event.Err))
+	}
+}
+
+// errorCategory groups a delivery failure by cause for /stats
+func errorCategory(err error) string {
+	switch {
+	case errors.Is(err, errProcessingTimeout), errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	case errors.Is(err, errAbandoned):
+		return "abandoned"
+	case errors.Is(err, github.ErrSecondaryRateLimited):
+		return "rate_limited"
+	case errors.Is(err, github.ErrNoRepoAccess):
+		return "no_repo_access"
+	case errors.Is(err, jira.ErrIssueNotFound):
+		return "issue_not_found"
+	case jira.IsTransitionNotAllowed(err):
+		return "transition_not_allowed"
+	case errors.As(err, new(*jira.FieldErrorsError)):
+		return "jira_fields_rejected"
+	}
+	return "other"
+}
+
+// StatsSnapshot is the /stats response
+type StatsSnapshot struct {
+	UptimeSeconds   int64            `json:"uptime_seconds"`
+	EventsReceived  map[string]int64 `json:"events_received"`
+	IssuesCreated   int64            `json:"issues_created"`
+	TransitionsDone int64            `json:"transitions_done"`
+	Errors          map[string]int64 `json:"errors"`
+	ErrorCategories map[string]int64 `json:"error_categories"`
+}
+
+// snapshot reads every counter, zeroing them when reset is set (uptime is never reset)
+func (s *serviceStats) snapshot(reset bool) StatsSnapshot {
+	load := func(counter *atomic.Int64) int64 {
+		if reset {
+			return counter.Swap(0)
+		}
+		return counter.Load()
+	}
+	return StatsSnapshot{
+		UptimeSeconds:   int64(time.Since(s.started).Seconds()),
+		EventsReceived:  s.eventsReceived.Snapshot(reset),
+		IssuesCreated:   load(&s.issuesCreated),
+		TransitionsDone: load(&s.transitionsDone),
+		Errors:          s.errors.Snapshot(reset),
+		ErrorCategories: s.errorCategories.Snapshot(reset),
+	}
+}
+
+// HandleStats serves a JSON dump of the in-memory counters
+func (h *WebhookHandler) HandleStats(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, h.stats.snapshot(false))
+}
+
+// HandleResetStats zeroes the counters, returning their values from just before the reset
+func (h *WebhookHandler) HandleResetStats(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, h.stats.snapshot(true))
+}
+
+// HandleRateLimits reports the current GitHub rate-limit state as JSON
+func (h *WebhookHandler) HandleRateLimits(w http.ResponseWriter, r *http.Request) {
+	limits, err := h.githubClient.WithContext(r.Context()).GetRateLimits()
+	if err != nil {
+		h.logger.Error(fmt.Sprintf("Failed to get GitHub rate limits: %v", err))
+		http.Error(w, "Failed to get GitHub rate limits", http.StatusBadGateway)
+		return
+	}
+	writeJSON(w, http.StatusOK, limits)
+}
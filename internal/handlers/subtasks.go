@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github_integration/internal/jira"
+)
+
+// componentsForFiles groups changed files by the component of their longest matching path prefix
+// (JIRA_SUBTASK_COMPONENTS); files matching no prefix stay on the parent issue only
+func (h *WebhookHandler) componentsForFiles(files []string) map[string][]string {
+	groups := make(map[string][]string)
+	for _, file := range files {
+		component, longest := "", -1
+		for prefix, name := range h.config.JiraSubTaskComponents {
+			if strings.HasPrefix(file, prefix) && len(prefix) > longest {
+				component, longest = name, len(prefix)
+			}
+		}
+		if component != "" {
+			groups[component] = append(groups[component], file)
+		}
+	}
+	return groups
+}
+
+// createSubTasks adds one sub-task per touched component under the PR's parent issue
+func (h *WebhookHandler) createSubTasks(client *jira.Client, prInfo jira.PRIssueInfo, parentKey string) {
+	groups := h.componentsForFiles(prInfo.FilesChanged)
+	components := make([]string, 0, len(groups))
+	for component := range groups {
+		components = append(components, component)
+	}
+	sort.Strings(components)
+
+	for _, component := range components {
+		subTask, err := client.CreatePRSubTask(parentKey, h.config.JiraSubTaskType, prInfo, component, groups[component])
+		if err != nil {
+			h.logger.Error(fmt.Sprintf("Failed to create %s sub-task for PR #%d: %v", component, prInfo.PRNumber, err))
+			h.result.fail(err)
+			continue
+		}
+		h.logger.Info(fmt.Sprintf("Created sub-task %s of %s for %s (%d files)", subTask.Key, parentKey, component, len(groups[component])))
+	}
+}
+
+// closeSubTasks moves a closed PR's sub-tasks to JIRA_SUBTASK_CLOSE_STATUS
+func (h *WebhookHandler) closeSubTasks(prInfo jira.PRIssueInfo) {
+	keys, err := h.jiraClient.ClosePRSubTasks(prInfo.RepoName, prInfo.PRNumber, h.config.JiraSubTaskCloseStatus)
+	if len(keys) > 0 {
+		h.logger.Info(fmt.Sprintf("Moved sub-tasks %s of PR #%d to %s", strings.Join(keys, ", "), prInfo.PRNumber, h.config.JiraSubTaskCloseStatus))
+	}
+	if err != nil {
+		h.logger.Error(fmt.Sprintf("Failed to close sub-tasks of PR #%d: %v", prInfo.PRNumber, err))
+		h.deadLetter("close_subtasks", prInfo.RepoName, prInfo.PRNumber, err)
+	}
+}
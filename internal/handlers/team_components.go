@@ -0,0 +1,29 @@
+package handlers
+
+import (
+	"fmt"
+	"sort"
+)
+
+// teamComponents returns the Jira components (JIRA_TEAM_COMPONENTS) of the teams with access to
+// the repository, sorted and without duplicates. Teams whose repos can't be listed are skipped.
+func (h *WebhookHandler) teamComponents(repoName string) []string {
+	seen := make(map[string]bool)
+	var components []string
+	for team, component := range h.config.JiraTeamComponents {
+		if component == "" || seen[component] {
+			continue
+		}
+		owns, err := h.githubClient.TeamHasRepo(team, repoName)
+		if err != nil {
+			h.logger.Error(fmt.Sprintf("WARNING: could not check whether team %s owns %s: %v", team, repoName, err))
+			continue
+		}
+		if owns {
+			seen[component] = true
+			components = append(components, component)
+		}
+	}
+	sort.Strings(components)
+	return components
+}
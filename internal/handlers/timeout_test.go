@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github_integration/internal/config"
+	"github_integration/internal/deadletter"
+	"github_integration/internal/github"
+	"github_integration/internal/jira"
+	"github_integration/internal/utils"
+)
+
+// memoryDeadLetters is an in-memory deadletter.Store
+type memoryDeadLetters struct {
+	mu      sync.Mutex
+	records []deadletter.Record
+}
+
+func (m *memoryDeadLetters) Add(record deadletter.Record) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.records = append(m.records, record)
+	return nil
+}
+
+func (m *memoryDeadLetters) List() ([]deadletter.Record, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]deadletter.Record(nil), m.records...), nil
+}
+
+func (m *memoryDeadLetters) Get(id string) (deadletter.Record, error) {
+	return deadletter.Record{}, errors.New("not implemented")
+}
+
+func (m *memoryDeadLetters) Update(record deadletter.Record) error { return nil }
+
+func (m *memoryDeadLetters) Remove(id string) error { return nil }
+
+func TestDispatchWithinDeadLettersTimedOutDelivery(t *testing.T) {
+	// A Jira that never answers before the delivery's deadline
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	jiraClient, err := jira.NewClient(server.URL, "user", "token", jira.Options{ProjectKey: "PROJ"})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	store := &memoryDeadLetters{}
+	h := &WebhookHandler{
+		githubClient: github.NewClient("token", "org"),
+		jiraClient:   jiraClient,
+		logger:       utils.NewLoggerWithWriters(io.Discard, io.Discard),
+		config:       &config.Config{EventProcessingTimeout: 50 * time.Millisecond},
+		ids:          utils.UUIDGen{},
+		deadLetters:  store,
+		ctx:          context.Background(),
+	}
+	scoped := h.withContext(context.Background())
+	scoped.result.source = delivery{id: "d-1", endpoint: "repo", eventType: github.EventPullRequest, body: []byte(`{}`)}
+
+	// Like most handlers, the dispatcher only logs the failed call
+	dispatch := func(h *WebhookHandler, _ github.EventType, _ interface{}) {
+		if _, err := h.jiraClient.FindPRIssue("api", 7); err != nil {
+			h.logger.Error(err.Error())
+		}
+	}
+	payload := map[string]interface{}{"repository": map[string]interface{}{"name": "api"}}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		scoped.dispatchWithin(dispatch, payload)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("dispatch did not stop at the deadline")
+	}
+
+	records, _ := store.List()
+	if len(records) != 1 {
+		t.Fatalf("got %d dead-letter records, want 1", len(records))
+	}
+	if records[0].Operation != "process_delivery" || records[0].Repo != "api" {
+		t.Errorf("dead-lettered %s for %q, want process_delivery for api", records[0].Operation, records[0].Repo)
+	}
+}
@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("github_integration/internal/handlers")
+
+// startEventSpan starts the root span for one webhook delivery and returns a handler copy
+// whose GitHub and Jira clients record their API calls as child spans.
+func (h *WebhookHandler) startEventSpan(r *http.Request, endpoint, eventType string) (*WebhookHandler, trace.Span) {
+	// Keep only the trace: async processing outlives the request, which must not be retained
+	ctx, span := tracer.Start(traceOnly(r.Context()), "webhook."+eventType,
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(
+			attribute.String("github.event", eventType),
+			attribute.String("github.delivery", r.Header.Get("X-GitHub-Delivery")),
+			attribute.String("github.hook_id", r.Header.Get("X-GitHub-Hook-ID")),
+			attribute.String("webhook.endpoint", endpoint),
+		))
+
+	return h.withContext(ctx), span
+}
+
+// withContext returns a shallow handler copy whose clients use ctx, with a fresh result
+func (h *WebhookHandler) withContext(ctx context.Context) *WebhookHandler {
+	scoped := h.withClientContext(ctx)
+	scoped.result = &eventResult{}
+	return scoped
+}
+
+// withClientContext returns a shallow handler copy whose clients use ctx, sharing h's result
+func (h *WebhookHandler) withClientContext(ctx context.Context) *WebhookHandler {
+	scoped := *h
+	scoped.ctx = ctx
+	scoped.githubClient = h.githubClient.WithContext(ctx)
+	if h.jiraClient != nil {
+		scoped.jiraClient = h.jiraClient.WithContext(ctx)
+	}
+	return &scoped
+}
+
+// detached returns a handler copy for background work that must outlive the delivery's deadline.
+// Only the delivery's span is carried over, so the delivery's context can be released.
+func (h *WebhookHandler) detached() *WebhookHandler {
+	return h.withContext(traceOnly(h.ctx))
+}
+
+// traceOnly returns a background context carrying just the span of ctx, without its values,
+// deadline or cancellation
+func traceOnly(ctx context.Context) context.Context {
+	return trace.ContextWithSpanContext(context.Background(), trace.SpanContextFromContext(ctx))
+}
@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github_integration/internal/jira"
+	"github_integration/internal/queue"
+)
+
+// transitionCooldown coalesces bursts of PR transitions (close/reopen flapping) into the final one
+type transitionCooldown struct {
+	mu      sync.Mutex
+	last    map[string]time.Time
+	pending map[string]*pendingTransition
+	// closed is set at shutdown, after which transitions are applied immediately
+	closed bool
+}
+
+// pendingTransition is the latest transition waiting for its PR's cooldown to pass
+type pendingTransition struct {
+	prInfo jira.PRIssueInfo
+	status string
+	// handler outlives the deferring delivery and keeps its source for dead-lettering
+	handler *WebhookHandler
+	timer   *time.Timer
+}
+
+func newTransitionCooldown() *transitionCooldown {
+	return &transitionCooldown{
+		last:    make(map[string]time.Time),
+		pending: make(map[string]*pendingTransition),
+	}
+}
+
+// coalesceTransition reports whether a PR transition was deferred because the PR transitioned
+// less than JIRA_TRANSITION_COOLDOWN ago. A deferred transition replaces any already waiting,
+// so only the final state is applied once the cooldown passes.
+func (h *WebhookHandler) coalesceTransition(prInfo jira.PRIssueInfo, status string) bool {
+	window := h.config.JiraTransitionCooldown
+	if window <= 0 {
+		return false
+	}
+
+	c := h.transitions
+	key := fmt.Sprintf("%s#%d", prInfo.RepoName, prInfo.PRNumber)
+	now := time.Now()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return false
+	}
+	if waiting, ok := c.pending[key]; ok {
+		h.logger.Info(fmt.Sprintf("Coalesced transition of PR #%d in %s: %s superseded by %s (action: %s)",
+			prInfo.PRNumber, prInfo.RepoName, waiting.status, status, prInfo.Action))
+		waiting.prInfo, waiting.status, waiting.handler = prInfo, status, h.background()
+		return true
+	}
+
+	// Forget PRs that have been quiet for longer than the window
+	for k, at := range c.last {
+		if now.Sub(at) >= window {
+			delete(c.last, k)
+		}
+	}
+
+	last, ok := c.last[key]
+	if !ok {
+		c.last[key] = now
+		return false
+	}
+
+	delay := window - now.Sub(last)
+	h.logger.Info(fmt.Sprintf("PR #%d in %s transitioned %s ago - deferring move to %s for %s",
+		prInfo.PRNumber, prInfo.RepoName, now.Sub(last).Round(time.Second), status, delay.Round(time.Second)))
+	c.pending[key] = &pendingTransition{
+		prInfo:  prInfo,
+		status:  status,
+		handler: h.background(),
+		timer:   time.AfterFunc(delay, func() { h.flushTransition(key) }),
+	}
+	return true
+}
+
+// background returns a handler copy that outlives the delivery but keeps its source, so work
+// deferred past the delivery's answer can still be dead-lettered against it
+func (h *WebhookHandler) background() *WebhookHandler {
+	scoped := h.detached()
+	if h.result != nil {
+		scoped.result.source = h.result.source
+	}
+	return scoped
+}
+
+// flushTransition applies the final transition waiting for a PR once its cooldown has passed
+func (h *WebhookHandler) flushTransition(key string) {
+	c := h.transitions
+	c.mu.Lock()
+	waiting, ok := c.pending[key]
+	delete(c.pending, key)
+	c.last[key] = time.Now()
+	c.mu.Unlock()
+	if !ok {
+		return
+	}
+	waiting.run()
+}
+
+// flushPendingTransitions stops deferring transitions and applies every one still waiting; it
+// runs at shutdown so a coalesced final state (e.g. a merge) isn't lost with its timer
+func (h *WebhookHandler) flushPendingTransitions() {
+	c := h.transitions
+	c.mu.Lock()
+	c.closed = true
+	pending := c.pending
+	c.pending = make(map[string]*pendingTransition)
+	c.mu.Unlock()
+
+	for _, waiting := range pending {
+		waiting.timer.Stop()
+		waiting.run()
+	}
+}
+
+// run applies the transition behind the PR's other queued deliveries, so it keeps their order.
+// Transitions the queue can't take, or drops at the shutdown deadline, are dead-lettered.
+func (p *pendingTransition) run() {
+	h := p.handler
+	if h.queue == nil {
+		h.handlePRTransition(p.prInfo, p.status)
+		return
+	}
+
+	err := h.queue.Enqueue(queue.Job{
+		ID:  h.result.source.id,
+		Key: h.result.source.key,
+		Run: func() { h.handlePRTransition(p.prInfo, p.status) },
+		Abandon: func() {
+			h.deadLetter("transition", p.prInfo.RepoName, p.prInfo.PRNumber, errAbandoned)
+		},
+	})
+	if err != nil {
+		h.logger.Error(fmt.Sprintf("Failed to queue deferred move of PR #%d in %s to %s: %v", p.prInfo.PRNumber, p.prInfo.RepoName, p.status, err))
+		h.deadLetter("transition", p.prInfo.RepoName, p.prInfo.PRNumber, err)
+	}
+}
@@ -0,0 +1,16 @@
+package handlers
+
+import (
+	gogithub "github.com/google/go-github/v56/github"
+
+	"github_integration/internal/github"
+)
+
+// parseEvent decodes a delivery body into go-github's typed event for eventType.
+// Event types go-github doesn't know parse to nil so they still reach the dispatcher's default case.
+func parseEvent(eventType github.EventType, body []byte) (interface{}, error) {
+	if gogithub.EventForType(string(eventType)) == nil {
+		return nil, nil
+	}
+	return gogithub.ParseWebHook(string(eventType), body)
+}
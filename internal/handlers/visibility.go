@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"fmt"
+	"time"
+
+	gogithub "github.com/google/go-github/v56/github"
+)
+
+// handleVisibilityChange records a repository going private or public as an audit trail,
+// commenting on the security tracking issue or filing one in the security project
+func (h *WebhookHandler) handleVisibilityChange(action string, event *gogithub.RepositoryEvent) {
+	name := event.GetRepo().GetName()
+	login := event.GetSender().GetLogin()
+	if login == "" {
+		login = "unknown"
+	}
+
+	visibility := "private"
+	if action == "publicized" {
+		visibility = "public"
+	}
+	at := time.Now().UTC().Format(time.RFC3339)
+
+	h.githubClient.InvalidateRepository(name)
+	h.logger.Error(h.tagged(fmt.Sprintf("WARNING: repository %s/%s was made %s by %s at %s",
+		h.config.GitHubOrg, name, visibility, login, at)))
+
+	if h.jiraClient == nil {
+		return
+	}
+
+	body := fmt.Sprintf("(!) *Visibility change:* repository {{%s/%s}} was made *%s* by %s at %s.",
+		h.config.GitHubOrg, name, visibility, login, at)
+
+	switch {
+	case h.config.JiraSecurityIssue != "":
+		key := h.config.JiraSecurityIssue
+		if err := h.jiraClient.AddComment(key, body+h.jiraMarker(key)); err != nil {
+			h.logger.Error(fmt.Sprintf("Failed to record visibility change of %s on %s: %v", name, key, err))
+			h.result.fail(err)
+			return
+		}
+		h.logger.Info(fmt.Sprintf("Recorded visibility change of %s on %s", name, key))
+	case h.config.JiraSecurityProject != "":
+		summary := fmt.Sprintf("Repository %s made %s by %s", name, visibility, login)
+		issue, err := h.jiraClient.InProject(h.config.JiraSecurityProject).CreateAuditIssue(summary, body)
+		if err != nil {
+			h.logger.Error(fmt.Sprintf("Failed to file visibility change of %s: %v", name, err))
+			h.result.fail(err)
+			return
+		}
+		h.result.setIssue(issue.Key)
+		h.logger.Info(fmt.Sprintf("Filed visibility change of %s as %s", name, issue.Key))
+	}
+}
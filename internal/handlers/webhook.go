@@ -1,15 +1,24 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
 	"net/http"
+	"path"
 	"strings"
 	"time"
 
+	gogithub "github.com/google/go-github/v56/github"
+
+	"github_integration/internal/config"
+	"github_integration/internal/deadletter"
+	"github_integration/internal/events"
 	"github_integration/internal/github"
 	"github_integration/internal/jira"
+	"github_integration/internal/notifier"
+	"github_integration/internal/queue"
 	"github_integration/internal/utils"
 )
 
@@ -17,36 +26,141 @@ type WebhookHandler struct {
 	githubClient *github.Client
 	jiraClient   *jira.Client
 	logger       *utils.Logger
+	config       *config.Config
+	queue        *queue.Queue
+	statuses     *statusStore
+	ids          utils.IDGen
+	deadLetters  deadletter.Store
+	events       *events.Bus
+	alerts       notifier.Notifier
+	pendingHooks *pendingHooks
+	stats        *serviceStats
+	transitions  *transitionCooldown
+
+	// ctx is the context the clients' API calls run under; scoped copies carry one delivery's
+	// context and are dropped with it
+	ctx context.Context
+	// result is only set on the per-delivery copy created for each webhook
+	result *eventResult
 }
 
-func NewWebhookHandler(githubClient *github.Client, jiraClient *jira.Client, logger *utils.Logger) *WebhookHandler {
-	return &WebhookHandler{
+func NewWebhookHandler(githubClient *github.Client, jiraClient *jira.Client, logger *utils.Logger, cfg *config.Config) *WebhookHandler {
+	h := &WebhookHandler{
 		githubClient: githubClient,
 		jiraClient:   jiraClient,
 		logger:       logger,
+		config:       cfg,
+		statuses:     newStatusStore(),
+		ids:          utils.UUIDGen{},
+		events:       events.NewBus(100),
+		pendingHooks: newPendingHooks(),
+		stats:        newServiceStats(),
+		transitions:  newTransitionCooldown(),
+		ctx:          context.Background(),
+	}
+	h.events.OnPanic(func(name string, recovered interface{}) {
+		logger.Error(fmt.Sprintf("Event subscriber %s panicked: %v", name, recovered))
+	})
+	h.events.Subscribe("status-buffer", h.recordStatus)
+	h.events.Subscribe("stats", h.stats.recordOutcome)
+
+	// Async mode acknowledges deliveries immediately and processes them on a worker pool
+	if cfg.WebhookAsync {
+		h.queue = queue.New(cfg.WebhookWorkers, cfg.WebhookQueueSize)
+		h.queue.Start()
+	}
+
+	return h
+}
+
+// SetIDGen replaces the generator used for correlation IDs (e.g. a deterministic one in tests)
+func (h *WebhookHandler) SetIDGen(ids utils.IDGen) {
+	h.ids = ids
+}
+
+// Events returns the bus processed deliveries are published on, for registering subscribers at startup
+func (h *WebhookHandler) Events() *events.Bus {
+	return h.events
+}
+
+// Shutdown stops accepting deliveries and drains the async queue until ctx is done; deliveries
+// still queued at the deadline are dead-lettered when a store is configured
+func (h *WebhookHandler) Shutdown(ctx context.Context) {
+	// Deferred transitions go on the queue ahead of the drain so no final state is lost
+	h.flushPendingTransitions()
+	if h.queue != nil {
+		processed, abandoned := h.queue.Shutdown(ctx)
+		msg := fmt.Sprintf("Webhook queue drained: %d deliveries processed during shutdown, %d abandoned", processed, abandoned)
+		if abandoned > 0 {
+			h.logger.Error("WARNING: " + msg)
+		} else {
+			h.logger.Info(msg)
+		}
+	}
+	h.events.Close()
+}
+
+// HandleWebhook processes every webhook on a single URL, routing org- and repo-level hooks
+// by GitHub's X-GitHub-Hook-Installation-Target-Type header
+func (h *WebhookHandler) HandleWebhook(w http.ResponseWriter, r *http.Request) {
+	switch target := r.Header.Get("X-GitHub-Hook-Installation-Target-Type"); target {
+	case "organization", "integration":
+		// GitHub App deliveries cover the whole installation, like an org hook
+		h.serveWebhook(w, r, "org", (*WebhookHandler).dispatchOrgEvent)
+	case "repository", "":
+		h.serveWebhook(w, r, "repo", (*WebhookHandler).dispatchRepoEvent)
+	default:
+		h.logger.Error(fmt.Sprintf("Unsupported hook target type %q (hook %s)", target, r.Header.Get("X-GitHub-Hook-ID")))
+		http.Error(w, "Unsupported hook target type", http.StatusBadRequest)
 	}
 }
 
-// HandleOrgWebhook processes organization-level webhook events
+// HandleOrgWebhook processes organization-level webhook events.
+// Deprecated: point the hook at /webhook instead.
 func (h *WebhookHandler) HandleOrgWebhook(w http.ResponseWriter, r *http.Request) {
-	// Read request body
-	body, err := io.ReadAll(r.Body)
-	if err != nil {
-		h.logger.Error(fmt.Sprintf("Failed to read request body: %v", err))
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+	w.Header().Set("Deprecation", "true")
+	h.serveWebhook(w, r, "org", (*WebhookHandler).dispatchOrgEvent)
+}
+
+// HandleRepoWebhook processes repository-level webhook events.
+// Deprecated: point the hook at /webhook instead.
+func (h *WebhookHandler) HandleRepoWebhook(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Deprecation", "true")
+	h.serveWebhook(w, r, "repo", (*WebhookHandler).dispatchRepoEvent)
+}
+
+// dispatcher routes a parsed delivery to its event handler
+type dispatcher func(h *WebhookHandler, eventType github.EventType, event interface{})
+
+// serveWebhook reads, verifies and parses a delivery, then dispatches it synchronously or via the queue
+func (h *WebhookHandler) serveWebhook(w http.ResponseWriter, r *http.Request, endpoint string, dispatch dispatcher) {
+	// Read and verify the body (capped to protect against oversized payloads)
+	body, ok := h.readPayload(w, r)
+	if !ok {
 		return
 	}
-	defer r.Body.Close()
 
 	// Get GitHub event type from headers
-	eventType := r.Header.Get("X-GitHub-Event")
-	if eventType == "" {
+	eventType, err := github.ParseEventType(gogithub.WebHookType(r))
+	if errors.Is(err, github.ErrMissingEventType) {
 		h.logger.Error("Missing X-GitHub-Event header")
 		http.Error(w, "Missing event type", http.StatusBadRequest)
 		return
 	}
+	if errors.Is(err, github.ErrUnknownEventType) {
+		// No handler knows this type, so the delivery is only recorded in the audit log
+		dispatch = func(h *WebhookHandler, eventType github.EventType, _ interface{}) {
+			h.handleAuditEvent(eventType)
+		}
+	}
 
-	// Parse JSON payload
+	// Parse into go-github's typed event, plus a generic view for delivery metadata
+	event, err := parseEvent(eventType, body)
+	if err != nil {
+		h.logger.Error(fmt.Sprintf("Failed to parse %s payload: %v", eventType, err))
+		http.Error(w, "Invalid JSON payload", http.StatusBadRequest)
+		return
+	}
 	var payload map[string]interface{}
 	if err := json.Unmarshal(body, &payload); err != nil {
 		h.logger.Error(fmt.Sprintf("Failed to parse JSON payload: %v", err))
@@ -54,251 +168,413 @@ func (h *WebhookHandler) HandleOrgWebhook(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	// Route to specific event handler
-	switch eventType {
-	case "repository":
-		h.handleRepositoryEvent(payload)
-	case "push":
-		h.handlePushEvent(payload)
-	case "pull_request":
-		h.handlePullRequestEvent(payload)
-	case "ping":
-		h.logger.Info("Received ping event from GitHub - webhook setup successful!")
-	default:
-		h.logger.Info(fmt.Sprintf("Received org-level event: %s", eventType))
+	id := h.correlationID(r)
+	h.stats.eventsReceived.Inc(string(eventType))
+	if !h.config.EventEnabled(eventType) {
+		h.logger.Debug(fmt.Sprintf("[%s] Skipping %s delivery - disabled by configuration", id, eventType))
+		writeJSON(w, http.StatusOK, WebhookResponse{Status: StatusOK, CorrelationID: id})
+		return
 	}
 
-	w.WriteHeader(http.StatusOK)
-	w.Write([]byte("Organization webhook processed successfully"))
-}
+	// Root span covering all processing for this delivery; the scoped copy traces API calls
+	scoped, span := h.startEventSpan(r, endpoint, string(eventType))
+	scoped.result.source = delivery{id: id, endpoint: endpoint, eventType: eventType, body: body, event: event, key: orderingKey(payload)}
+	scoped.applyProfile(payloadOrg(payload))
 
-// HandleRepoWebhook processes repository-level webhook events
-func (h *WebhookHandler) HandleRepoWebhook(w http.ResponseWriter, r *http.Request) {
-	// Read request body
-	body, err := io.ReadAll(r.Body)
-	if err != nil {
-		h.logger.Error(fmt.Sprintf("Failed to read request body: %v", err))
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+	if h.queue == nil {
+		defer span.End()
+		scoped.process(dispatch, payload)
+		writeJSON(w, http.StatusOK, scoped.result.response(id))
 		return
 	}
-	defer r.Body.Close()
 
-	// Get GitHub event type from headers
-	eventType := r.Header.Get("X-GitHub-Event")
-	if eventType == "" {
-		h.logger.Error("Missing X-GitHub-Event header")
-		http.Error(w, "Missing event type", http.StatusBadRequest)
+	// Record acceptance first so a fast worker's final status isn't overwritten
+	h.statuses.set(id, WebhookResponse{Status: StatusAccepted, CorrelationID: id})
+	err = h.queue.Enqueue(queue.Job{ID: id, Key: scoped.result.source.key, Run: func() {
+		defer span.End()
+		scoped.process(dispatch, payload)
+	}, Abandon: func() {
+		defer span.End()
+		scoped.abandon(payload)
+	}})
+	if err != nil {
+		span.End()
+		h.logger.Error(fmt.Sprintf("Failed to queue %s delivery %s: %v", eventType, id, err))
+		resp := WebhookResponse{Status: StatusError, CorrelationID: id, Error: err.Error()}
+		h.statuses.set(id, resp)
+		writeJSON(w, http.StatusServiceUnavailable, resp)
 		return
 	}
 
-	// Parse JSON payload
-	var payload map[string]interface{}
-	if err := json.Unmarshal(body, &payload); err != nil {
-		h.logger.Error(fmt.Sprintf("Failed to parse JSON payload: %v", err))
-		http.Error(w, "Invalid JSON payload", http.StatusBadRequest)
+	writeJSON(w, http.StatusAccepted, WebhookResponse{Status: StatusAccepted, CorrelationID: id})
+}
+
+var (
+	// errAbandoned marks deliveries still queued when the shutdown deadline passed
+	errAbandoned = errors.New("delivery abandoned at shutdown before processing")
+	// errProcessingTimeout marks deliveries that overran EVENT_PROCESSING_TIMEOUT
+	errProcessingTimeout = errors.New("delivery abandoned after exceeding the event processing timeout")
+)
+
+// process dispatches a delivery on its scoped handler and publishes the outcome
+func (h *WebhookHandler) process(dispatch dispatcher, payload map[string]interface{}) {
+	started := time.Now()
+	h.recordDeliveryLatency(started)
+	h.dispatchWithin(dispatch, payload)
+	h.publish(payload, started)
+}
+
+// dispatchWithin runs dispatch under EVENT_PROCESSING_TIMEOUT. The deadline bounds every GitHub
+// and Jira call of the delivery, retries included; once it passes the remaining calls fail fast and
+// the whole delivery is dead-lettered once instead of per failed operation. Handlers often only log
+// a failed call, so the deadline itself, not a recorded failure, decides.
+func (h *WebhookHandler) dispatchWithin(dispatch dispatcher, payload map[string]interface{}) {
+	eventType := h.result.source.eventType
+	if h.config.EventProcessingTimeout <= 0 {
+		dispatch(h, eventType, h.result.source.event)
 		return
 	}
 
-	// Route to specific event handler with enhanced details
-	switch eventType {
-	case "push":
-		h.handlePushEventDetailed(payload)
-	case "pull_request":
-		h.handlePullRequestEventDetailed(payload)
-	case "ping":
-		h.logger.Info("Received ping event from GitHub - repo webhook setup successful!")
-	default:
-		h.logger.Info(fmt.Sprintf("Received repo-level event: %s", eventType))
+	ctx, cancel := context.WithTimeout(h.ctx, h.config.EventProcessingTimeout)
+	defer cancel()
+	bounded := h.withClientContext(ctx)
+	dispatch(bounded, eventType, h.result.source.event)
+
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		h.logger.Error(h.tagged(fmt.Sprintf("Abandoned %s delivery after %s", eventType, h.config.EventProcessingTimeout)))
+		repoName, prNumber := payloadPR(payload)
+		h.deadLetter("process_delivery", repoName, prNumber, errProcessingTimeout)
 	}
+}
 
-	w.WriteHeader(http.StatusOK)
-	w.Write([]byte("Repository webhook processed successfully"))
+// abandon dead-letters a queued delivery dropped at shutdown so it can be replayed later
+func (h *WebhookHandler) abandon(payload map[string]interface{}) {
+	repoName, prNumber := payloadPR(payload)
+	h.deadLetter("process_delivery", repoName, prNumber, errAbandoned)
+	h.publish(payload, time.Now())
 }
 
-// handleRepositoryEvent processes new repository creation
-func (h *WebhookHandler) handleRepositoryEvent(payload map[string]interface{}) {
-	action, ok := payload["action"].(string)
-	if !ok || action != "created" {
-		return // Only handle repository creation
+// payloadPR returns the repository and, for PR deliveries (including comments on a PR), the
+// PR number a payload concerns; the number is 0 for anything else
+func payloadPR(payload map[string]interface{}) (string, int) {
+	repoData, _ := payload["repository"].(map[string]interface{})
+	repoName, _ := repoData["name"].(string)
+
+	if pr, ok := payload["pull_request"].(map[string]interface{}); ok {
+		number, _ := pr["number"].(float64)
+		return repoName, int(number)
 	}
+	if issue, ok := payload["issue"].(map[string]interface{}); ok && issue["pull_request"] != nil {
+		number, _ := issue["number"].(float64)
+		return repoName, int(number)
+	}
+	return repoName, 0
+}
 
-	// Extract repository information
-	repo, ok := payload["repository"].(map[string]interface{})
-	if !ok {
+// orderingKey groups deliveries that must be processed in arrival order, so a PR's merge can't
+// overtake its opening on the worker pool. Deliveries not about a PR are unordered.
+func orderingKey(payload map[string]interface{}) string {
+	repoName, prNumber := payloadPR(payload)
+	if prNumber == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%s/%s#%d", payloadOrg(payload), repoName, prNumber)
+}
+
+// publish reports a delivery's outcome to event subscribers
+func (h *WebhookHandler) publish(payload map[string]interface{}, started time.Time) {
+	source := h.result.source
+	action, _ := payload["action"].(string)
+	repoName, prNumber := payloadPR(payload)
+	sender, _ := payload["sender"].(map[string]interface{})
+	actor, _ := sender["login"].(string)
+	pr, _ := payload["pull_request"].(map[string]interface{})
+	merged, _ := pr["merged"].(bool)
+	h.events.Publish(events.ProcessedEvent{
+		ID:        source.id,
+		EventType: string(source.eventType),
+		Action:    action,
+		Endpoint:  source.endpoint,
+		Repo:      repoName,
+		PRNumber:  prNumber,
+		Merged:    merged,
+		Actor:     actor,
+		Issue:     h.result.issueKey,
+		Err:       h.result.err,
+		Duration:  time.Since(started),
+		Timestamp: started,
+	})
+}
+
+// dispatchOrgEvent routes organization-level events
+func (h *WebhookHandler) dispatchOrgEvent(eventType github.EventType, event interface{}) {
+	switch event := event.(type) {
+	case *gogithub.RepositoryEvent:
+		h.handleRepositoryEvent(event)
+	case *gogithub.PushEvent:
+		h.handlePushEvent(event)
+	case *gogithub.PullRequestEvent:
+		h.handlePullRequestEvent(event)
+	case *gogithub.DeploymentStatusEvent:
+		h.logRepoHookEvent(eventType, event)
+	case *gogithub.CheckRunEvent:
+		h.logRepoHookEvent(eventType, event)
+	case *gogithub.CheckSuiteEvent:
+		h.logRepoHookEvent(eventType, event)
+	case *gogithub.IssueCommentEvent:
+		h.logRepoHookEvent(eventType, event)
+	case *gogithub.InstallationEvent:
+		h.handleInstallationEvent(event)
+	case *gogithub.InstallationRepositoriesEvent:
+		h.handleInstallationRepositoriesEvent(event)
+	case *gogithub.PingEvent:
+		h.logger.Info("Received ping event from GitHub - webhook setup successful!")
+	default:
+		h.handleAuditEvent(eventType)
+	}
+}
+
+// dispatchRepoEvent routes repository-level events with enhanced details
+func (h *WebhookHandler) dispatchRepoEvent(eventType github.EventType, event interface{}) {
+	switch event := event.(type) {
+	case *gogithub.PushEvent:
+		h.handlePushEventDetailed(event)
+	case *gogithub.PullRequestEvent:
+		h.handlePullRequestEventDetailed(event)
+	case *gogithub.DeploymentStatusEvent:
+		h.handleDeploymentStatusEvent(event)
+	case *gogithub.CheckRunEvent:
+		h.handleCheckRunEvent(event)
+	case *gogithub.CheckSuiteEvent:
+		h.handleCheckSuiteEvent(event)
+	case *gogithub.IssueCommentEvent:
+		h.handleIssueCommentEvent(event)
+	case *gogithub.PingEvent:
+		h.logger.Info("Received ping event from GitHub - repo webhook setup successful!")
+	default:
+		h.handleAuditEvent(eventType)
+	}
+}
+
+// logRepoHookEvent notes an org delivery of an event only the repository webhook acts on: repository
+// hooks receive their own copy, so handling both would post comments and transition issues twice
+func (h *WebhookHandler) logRepoHookEvent(eventType github.EventType, event interface{ GetRepo() *gogithub.Repository }) {
+	h.logger.Info(fmt.Sprintf("%s event in repo %s - handled by the repository webhook", eventType, event.GetRepo().GetName()))
+}
+
+// handleRepositoryEvent processes repository lifecycle events
+func (h *WebhookHandler) handleRepositoryEvent(event *gogithub.RepositoryEvent) {
+	if event.Repo == nil {
 		h.logger.Error("Invalid repository data in payload")
 		return
 	}
 
-	sender, _ := payload["sender"].(map[string]interface{})
+	switch action := event.GetAction(); action {
+	case "created":
+		h.handleRepositoryCreated(event)
+	case "edited":
+		name := event.GetRepo().GetName()
+		h.githubClient.InvalidateRepository(name)
+		h.logger.Info(fmt.Sprintf("Repository %s edited - cached details invalidated", name))
+	case "privatized", "publicized":
+		h.handleVisibilityChange(action, event)
+	case "renamed":
+		h.handleRepositoryRenamed(event)
+	case "transferred":
+		h.handleRepositoryTransferred(event)
+	case "archived":
+		h.handleRepositoryArchived(event)
+	}
+}
 
+// handleRepositoryCreated logs a new repository and registers the webhook on it
+func (h *WebhookHandler) handleRepositoryCreated(event *gogithub.RepositoryEvent) {
 	// Build detailed repository creation info
-	repoInfo := h.extractRepoInfo(repo, sender)
+	repoInfo := extractRepoInfo(event.GetRepo(), event.Sender)
 
 	// Log production-level new repository information
 	h.logNewRepository(repoInfo)
 
 	// Automatically add webhook to the new repository
-	webhookURL := "your url" // Your current ngrok URL
-	if err := h.githubClient.CreateRepoWebhook(repoInfo.RepoName, webhookURL); err != nil {
-		h.logger.Error(fmt.Sprintf("Failed to add webhook to new repo %s: %v", repoInfo.RepoName, err))
-	} else {
-		h.logger.Info(fmt.Sprintf("Successfully added webhook to new repo: %s", repoInfo.RepoName))
-	}
+	h.registerRepoWebhook(repoInfo.RepoName)
 }
 
 // handlePushEvent handles basic push events from organization webhook
-func (h *WebhookHandler) handlePushEvent(payload map[string]interface{}) {
-	repoData, _ := payload["repository"].(map[string]interface{})
-	repoName, _ := repoData["name"].(string)
-	pusher, _ := payload["pusher"].(map[string]interface{})
-	pusherName, _ := pusher["name"].(string)
-
-	h.logger.Info(fmt.Sprintf("Push event detected in repo: %s by %s", repoName, pusherName))
+func (h *WebhookHandler) handlePushEvent(event *gogithub.PushEvent) {
+	h.logger.Info(fmt.Sprintf("Push event detected in repo: %s by %s", event.GetRepo().GetName(), event.GetPusher().GetName()))
 }
 
 // handlePushEventDetailed handles detailed push events with file diffs
-func (h *WebhookHandler) handlePushEventDetailed(payload map[string]interface{}) {
+func (h *WebhookHandler) handlePushEventDetailed(event *gogithub.PushEvent) {
 	// Extract basic push information
-	repoData, _ := payload["repository"].(map[string]interface{})
-	repoName, _ := repoData["name"].(string)
-	ref, _ := payload["ref"].(string)
-	branch := strings.TrimPrefix(ref, "refs/heads/")
-
-	pusher, _ := payload["pusher"].(map[string]interface{})
-	pusherName, _ := pusher["name"].(string)
-
-	// Extract commits from payload
-	//another comment added
-	//added a comment
-	//why not add another comment
-	//lets do some tests
-	commits, ok := payload["commits"].([]interface{})
-	if !ok {
+	repoName := event.GetRepo().GetName()
+	pusherName := event.GetPusher().GetName()
+
+	// Only refs/heads/* are branch pushes; tags and other refs have no branch to report
+	kind, branch := parseRef(event.GetRef())
+	switch kind {
+	case refTag:
+		h.handleTagPush(event, branch)
+		return
+	case refOther:
+		h.logger.Info(fmt.Sprintf("Ignoring push to %s in %s by %s", branch, repoName, pusherName))
+		return
+	}
+
+	before, after := event.GetBefore(), event.GetAfter()
+	if event.GetForced() {
+		h.handleForcePush(repoName, branch, pusherName, before, after)
+		return
+	}
+
+	// Distinguish a missing commits array from an empty one (e.g. a branch deletion)
+	commits := event.Commits
+	if commits == nil {
 		h.logger.Error("No commits found in push payload")
 		return
 	}
 
-	h.logger.Info(fmt.Sprintf("DETAILED PUSH EVENT - Repo: %s, Branch: %s, Pusher: %s, Commits: %d",
-		repoName, branch, pusherName, len(commits)))
+	// The whole event is logged as one block so concurrent deliveries don't interleave
+	var block strings.Builder
+	fmt.Fprintf(&block, "DETAILED PUSH EVENT - Repo: %s, Branch: %s, Pusher: %s, Commits: %d\n",
+		repoName, branch, pusherName, len(commits))
 
-	// Process each commit with full details
-	for i, commitInterface := range commits {
-		commitData, ok := commitInterface.(map[string]interface{})
-		if !ok {
-			continue
-		}
+	// Large pushes that fast-forward are summarised from one compare call; small pushes
+	// (and ranges that turn out not to connect) fetch each commit individually
+	processed, compared := h.describePushRange(&block, repoName, branch, before, after, commits)
+	if !compared {
+		processed = h.describeCommits(&block, repoName, branch, commits)
+	}
+	h.logBlock(block.String())
 
-		commitSHA, _ := commitData["id"].(string)
-		message, _ := commitData["message"].(string)
-		author, _ := commitData["author"].(map[string]interface{})
-		authorName, _ := author["name"].(string)
-		authorEmail, _ := author["email"].(string)
+	if h.jiraClient != nil && h.config.JiraMirrorPushes && len(processed) > 0 {
+		h.mirrorPushToJira(repoName, branch, pusherName, processed)
+	}
+}
+
+// describeCommits fetches each pushed commit's details and diff, writing them to the event's log block
+// and collecting them for a single Jira comment
+func (h *WebhookHandler) describeCommits(block *strings.Builder, repoName, branch string, commits []*gogithub.HeadCommit) []github.CommitInfo {
+	var processed []github.CommitInfo
+	for i, commit := range commits {
+		commitSHA := commit.GetID()
+		message := commit.GetMessage()
+		authorName := commit.GetAuthor().GetName()
+		authorEmail := commit.GetAuthor().GetEmail()
 
 		// Get detailed commit information via GitHub API
 		commitDetails, err := h.githubClient.GetCommitDetails(repoName, commitSHA)
 		if err != nil {
-			h.logger.Error(fmt.Sprintf("Failed to get commit details: %v", err))
+			h.logger.Error(h.tagged(fmt.Sprintf("Failed to get commit details: %v", err)))
 			continue
 		}
 
+		// A merge commit's diff is the merge resolution, which is usually noise
+		isMerge := len(commitDetails.Parents) > 1
+
 		// Get file diffs
-		diffContent, err := h.githubClient.GetFileDiff(repoName, commitSHA)
-		if err != nil {
-			h.logger.Error(fmt.Sprintf("Failed to get file diff: %v", err))
+		var diffContent string
+		if isMerge && h.config.PushSkipMergeDiffs {
+			diffContent = "Merge commit - diff skipped"
+		} else if diffContent, err = h.githubClient.GetFileDiff(repoName, commitSHA); err != nil {
+			h.logger.Error(h.tagged(fmt.Sprintf("Failed to get file diff: %v", err)))
 			diffContent = "Diff unavailable"
 		}
 
-		// Build production commit info
+		// Build production commit info; ignored files don't count, as in the diff
+		filesChanged, additions, deletions := h.githubClient.ChangeStats(commitDetails.Files)
 		commitInfo := github.CommitInfo{
 			SHA:          commitSHA,
 			Message:      message,
 			Author:       authorName,
 			AuthorEmail:  authorEmail,
 			Date:         time.Now().Format(time.RFC3339),
-			FilesChanged: len(commitDetails.Files),
-			Additions:    commitDetails.Stats.GetAdditions(),
-			Deletions:    commitDetails.Stats.GetDeletions(),
+			FilesChanged: filesChanged,
+			Additions:    additions,
+			Deletions:    deletions,
 			Repository:   repoName,
 			Branch:       branch,
 			DiffContent:  diffContent,
+			IsMerge:      isMerge,
 		}
 
 		// Log comprehensive commit information
-		h.logDetailedCommit(i+1, commitInfo)
+		writeDetailedCommit(block, i+1, commitInfo)
+		processed = append(processed, commitInfo)
 	}
+	return processed
 }
 
 // handlePullRequestEvent handles basic PR events from organization webhook
-func (h *WebhookHandler) handlePullRequestEvent(payload map[string]interface{}) {
-	action, _ := payload["action"].(string)
-	prData, _ := payload["pull_request"].(map[string]interface{})
-	title, _ := prData["title"].(string)
-	number, _ := prData["number"].(float64)
-
-	h.logger.Info(fmt.Sprintf("PR event: %s - #%.0f: %s", action, number, title))
+func (h *WebhookHandler) handlePullRequestEvent(event *gogithub.PullRequestEvent) {
+	pr := event.GetPullRequest()
+	h.logger.Info(fmt.Sprintf("PR event: %s - #%d: %s", event.GetAction(), pr.GetNumber(), pr.GetTitle()))
 }
 
 // handlePullRequestEventDetailed with Jira integration
-func (h *WebhookHandler) handlePullRequestEventDetailed(payload map[string]interface{}) {
-	action, _ := payload["action"].(string)
-	prData, _ := payload["pull_request"].(map[string]interface{})
-	repoData, _ := payload["repository"].(map[string]interface{})
-
-	repoName, _ := repoData["name"].(string)
-	prNumber := int(prData["number"].(float64))
-	title, _ := prData["title"].(string)
-	user, _ := prData["user"].(map[string]interface{})
-	userName, _ := user["login"].(string)
-
-	// Extract PR branch information for Jira
-	head, _ := prData["head"].(map[string]interface{})
-	base, _ := prData["base"].(map[string]interface{})
-	sourceBranch, _ := head["ref"].(string)
-	targetBranch, _ := base["ref"].(string)
-	prURL, _ := prData["html_url"].(string)
+func (h *WebhookHandler) handlePullRequestEventDetailed(event *gogithub.PullRequestEvent) {
+	action := event.GetAction()
+	repoName := event.GetRepo().GetName()
+	prNumber := event.GetPullRequest().GetNumber()
+	userName := event.GetPullRequest().GetUser().GetLogin()
 
 	h.logger.Info(fmt.Sprintf("DETAILED PR EVENT - Action: %s, Repo: %s, PR #%d by %s",
 		action, repoName, prNumber, userName))
 
 	// Get comprehensive PR details via GitHub API (existing logic)
 	prDetails, err := h.githubClient.GetPullRequestDetails(repoName, prNumber)
+	if errors.Is(err, github.ErrNoRepoAccess) {
+		h.logger.Error(fmt.Sprintf("WARNING: cannot read PR #%d in %s - the GitHub token or app has no access to this repository. "+
+			"Grant it access (repo scope for a classic token, the repository for a fine-grained token or app installation): %v", prNumber, repoName, err))
+		h.result.fail(err)
+		return
+	}
 	if err != nil {
 		h.logger.Error(fmt.Sprintf("Failed to get PR details: %v", err))
 		return
 	}
 
-	// Extract changed files for Jira
-	var changedFiles []string
-	for _, file := range prDetails.Files {
-		changedFiles = append(changedFiles, file.GetFilename())
-	}
-
 	// Build PR info for Jira integration
-	prInfo := jira.PRIssueInfo{
-		PRNumber:     prNumber,
-		PRTitle:      title,
-		RepoName:     repoName,
-		Author:       userName,
-		SourceBranch: sourceBranch,
-		TargetBranch: targetBranch,
-		FilesChanged: changedFiles,
-		PRLink:       prURL,
-		Action:       action,
-	}
+	prInfo := buildPRIssueInfo(repoName, prDetails, action)
 
 	// Handle different PR actions with Jira integration
 	if h.jiraClient != nil {
 		switch action {
 		case "opened":
+			if reason := h.skipReason(prDetails); reason != "" {
+				h.logger.Info(fmt.Sprintf("Skipping Jira issue for PR #%d in %s: %s", prNumber, repoName, reason))
+				h.commentSkipReason(prInfo, reason)
+				break
+			}
 			h.handlePROpened(prInfo)
-		case "closed":
-			merged, _ := prData["merged"].(bool)
-			if merged {
-				prInfo.Action = "merged"
-				h.handlePRMerged(prInfo)
+		case "assigned", "unassigned":
+			h.handlePRAssignment(prInfo, event.GetAssignee().GetLogin())
+		case "labeled", "unlabeled":
+			name := event.GetLabel().GetName()
+			if action == "labeled" && h.isOptOutLabel(name) {
+				h.handlePROptOut(prInfo)
+			} else if h.config.JiraSyncLabels {
+				h.syncPRLabel(prInfo, name, action == "labeled")
 			}
+		case "review_requested", "review_request_removed":
+			h.handleReviewRequest(prInfo, event)
+		case "locked", "unlocked":
+			h.handlePRLock(prInfo, event)
+		case "edited":
+			h.syncRemoteLinks(prInfo)
 		case "synchronize": // PR updated with new commits
 			h.logger.Info(fmt.Sprintf("PR #%d updated - keeping existing Jira issue", prNumber))
+		default:
+			// A merged PR arrives as "closed"; map it to the "merged" pseudo-action
+			if action == "closed" && event.GetPullRequest().GetMerged() {
+				prInfo.Action = "merged"
+			}
+			if status, ok := h.config.TransitionFor(github.EventPullRequest, prInfo.Action); ok && !h.coalesceTransition(prInfo, status) {
+				h.handlePRTransition(prInfo, status)
+			}
+			if action == "closed" && h.config.JiraSubTaskMode {
+				h.closeSubTasks(prInfo)
+			}
 		}
 	}
 
@@ -306,30 +582,297 @@ func (h *WebhookHandler) handlePullRequestEventDetailed(payload map[string]inter
 	h.logDetailedPR(action, prDetails)
 }
 
+// buildPRIssueInfo converts fetched PR details into the info used for Jira issues
+func buildPRIssueInfo(repoName string, details *github.PRDetails, action string) jira.PRIssueInfo {
+	pr := details.PullRequest
+
+	var changedFiles []string
+	for _, file := range details.Files {
+		changedFiles = append(changedFiles, file.GetFilename())
+	}
+
+	return jira.PRIssueInfo{
+		PRNumber:     pr.GetNumber(),
+		PRTitle:      pr.GetTitle(),
+		PRBody:       pr.GetBody(),
+		RepoName:     repoName,
+		Author:       pr.GetUser().GetLogin(),
+		SourceBranch: pr.GetHead().GetRef(),
+		TargetBranch: pr.GetBase().GetRef(),
+		FilesChanged: changedFiles,
+		IgnoredFiles: details.IgnoredFiles,
+		ClosesIssues: github.ParseClosingReferences(pr.GetBody(), pr.GetBase().GetRepo().GetOwner().GetLogin(), repoName),
+		DependsOn:    github.ParseDependencyReferences(pr.GetBody(), pr.GetBase().GetRepo().GetOwner().GetLogin(), repoName),
+		PRLink:       pr.GetHTMLURL(),
+		Action:       action,
+	}
+}
+
 // New function: Handle PR opened - create Jira issue
 func (h *WebhookHandler) handlePROpened(prInfo jira.PRIssueInfo) {
-	h.logger.Info(fmt.Sprintf("Creating Jira issue for PR #%d in %s", prInfo.PRNumber, prInfo.RepoName))
+	for _, project := range h.projectsForFiles(prInfo.RepoName, prInfo.FilesChanged) {
+		h.createPRIssue(prInfo, project)
+	}
+}
 
-	issue, err := h.jiraClient.CreatePRIssue(prInfo)
+// createPRIssue creates the PR's issue in project, then links and announces it
+func (h *WebhookHandler) createPRIssue(prInfo jira.PRIssueInfo, project string) {
+	h.logger.Info(fmt.Sprintf("Creating Jira issue for PR #%d in %s (project %s)", prInfo.PRNumber, prInfo.RepoName, project))
+
+	client := h.jiraClient.InProject(project)
+	prInfo.ReporterAccountID = h.reporterFor(prInfo)
+	prInfo.IssueType = h.issueTypeFor(client, prInfo)
+	prInfo.ExtraLabels = h.extensionLabels(prInfo.FilesChanged)
+	prInfo.Components = h.teamComponents(prInfo.RepoName)
+	issue, err := client.CreatePRIssue(prInfo)
+	if len(prInfo.Components) > 0 && jira.IsFieldRejected(err, "components") {
+		h.logger.Error(fmt.Sprintf("WARNING: Jira rejected components %s for PR #%d (not defined in project %s?) - creating the issue without them",
+			strings.Join(prInfo.Components, ", "), prInfo.PRNumber, project))
+		prInfo.Components = nil
+		issue, err = client.CreatePRIssue(prInfo)
+	}
+	if prInfo.ReporterAccountID != "" && jira.IsFieldRejected(err, "reporter") {
+		h.logger.Info(fmt.Sprintf("Jira rejected %s as reporter (missing Modify Reporter permission?) - creating PR #%d's issue with the default reporter",
+			prInfo.Author, prInfo.PRNumber))
+		prInfo.ReporterAccountID = ""
+		issue, err = client.CreatePRIssue(prInfo)
+	}
 	if err != nil {
 		h.logger.Error(fmt.Sprintf("Failed to create Jira issue: %v", err))
+		h.deadLetter("create_issue", prInfo.RepoName, prInfo.PRNumber, err)
 		return
 	}
+	h.result.setIssue(issue.Key)
+	h.stats.issuesCreated.Add(1)
+
+	if h.config.JiraSubTaskMode {
+		h.createSubTasks(client, prInfo, issue.Key)
+	}
+
+	h.logger.Info(fmt.Sprintf("Created Jira issue: %s for PR #%d in %s status", issue.Key, prInfo.PRNumber, h.jiraClient.OpenStatus()))
 
-	h.logger.Info(fmt.Sprintf("Created Jira issue: %s for PR #%d in Open_PR status", issue.Key, prInfo.PRNumber))
+	if h.config.JiraLinkReferencedIssues {
+		h.linkReferencedIssues(prInfo, issue.Key)
+	}
+	if h.config.JiraLinkDependencies {
+		h.linkDependencies(prInfo, issue.Key)
+		h.linkWaitingDependents(prInfo, issue.Key)
+	}
+
+	if h.config.CommentJiraLink {
+		h.commentJiraLink(prInfo, issue.Key)
+	}
 }
 
-// New function: Handle PR merged - move to merged status
-func (h *WebhookHandler) handlePRMerged(prInfo jira.PRIssueInfo) {
-	h.logger.Info(fmt.Sprintf("Moving PR #%d to Merged_PR status in Jira", prInfo.PRNumber))
+// reporterFor returns the Jira account to report the PR's issue as, or "" for the API token's account
+func (h *WebhookHandler) reporterFor(prInfo jira.PRIssueInfo) string {
+	if !h.config.JiraSetReporter {
+		return ""
+	}
+	accountID, ok := h.jiraAccountFor("", prInfo.Author)
+	if !ok {
+		h.logger.Info(fmt.Sprintf("No Jira user mapping for PR author %s - using the default reporter", prInfo.Author))
+		return ""
+	}
+	h.logger.Info(fmt.Sprintf("Reporting PR #%d's issue as %s's Jira account", prInfo.PRNumber, prInfo.Author))
+	return accountID
+}
+
+// linkReferencedIssues links the PR's issue to every Jira issue mentioned in the PR title or body
+func (h *WebhookHandler) linkReferencedIssues(prInfo jira.PRIssueInfo, issueKey string) {
+	for _, key := range jira.ParseIssueKeys(prInfo.PRTitle + "\n" + prInfo.PRBody) {
+		// The epic is already linked through the epic link field
+		if key == issueKey || key == jira.ParseEpicKey(prInfo.PRTitle) || key == jira.ParseEpicKey(prInfo.PRBody) {
+			continue
+		}
+		if err := h.jiraClient.LinkIssues(issueKey, key, h.config.JiraIssueLinkType); err != nil {
+			h.logger.Error(fmt.Sprintf("Failed to link %s to referenced issue %s: %v", issueKey, key, err))
+			continue
+		}
+		h.logger.Info(fmt.Sprintf("Linked %s to referenced issue %s", issueKey, key))
+	}
+}
+
+// commentJiraLink posts the Jira issue link on the PR unless one was already posted
+func (h *WebhookHandler) commentJiraLink(prInfo jira.PRIssueInfo, issueKey string) {
+	marker := h.githubMarker(issueKey)
+
+	exists, err := h.githubClient.PRCommentExists(prInfo.RepoName, prInfo.PRNumber, marker)
+	if err != nil {
+		h.logger.Error(fmt.Sprintf("Failed to check existing comments on PR #%d: %v", prInfo.PRNumber, err))
+		return
+	}
+	if exists {
+		h.logger.Info(fmt.Sprintf("Jira link for %s already posted on PR #%d", issueKey, prInfo.PRNumber))
+		return
+	}
 
-	err := h.jiraClient.MovePRToMerged(prInfo.RepoName, prInfo.PRNumber)
+	body := fmt.Sprintf("Tracked in Jira: [%s](%s)\n\n%s", issueKey, h.jiraClient.IssueURL(issueKey), marker)
+	if err := h.githubClient.CommentOnPR(prInfo.RepoName, prInfo.PRNumber, body); err != nil {
+		h.logger.Error(fmt.Sprintf("Failed to post Jira link on PR #%d: %v", prInfo.PRNumber, err))
+		return
+	}
+	h.logger.Info(fmt.Sprintf("Posted Jira link %s on PR #%d", issueKey, prInfo.PRNumber))
+}
+
+// syncPRLabel mirrors a PR label being added or removed onto its Jira issues
+func (h *WebhookHandler) syncPRLabel(prInfo jira.PRIssueInfo, label string, add bool) {
+	keys, err := h.jiraClient.SyncPRLabel(prInfo.RepoName, prInfo.PRNumber, label, add)
+	if errors.Is(err, jira.ErrIssueNotFound) {
+		h.logger.Info(fmt.Sprintf("PR #%d in %s has no Jira issue - label %q not synced", prInfo.PRNumber, prInfo.RepoName, label))
+		return
+	}
 	if err != nil {
-		h.logger.Error(fmt.Sprintf("Failed to move PR to merged: %v", err))
+		h.logger.Error(fmt.Sprintf("Failed to sync label %q for PR #%d: %v", label, prInfo.PRNumber, err))
+		h.result.fail(err)
 		return
 	}
+	verb := "Added"
+	if !add {
+		verb = "Removed"
+	}
+	h.logger.Info(fmt.Sprintf("%s label %q on %s for PR #%d", verb, label, strings.Join(keys, ", "), prInfo.PRNumber))
+}
 
-	h.logger.Info(fmt.Sprintf("Moved PR #%d to Merged_PR status successfully", prInfo.PRNumber))
+// skipReason returns why a PR should not get a Jira issue, or "" if it should
+func (h *WebhookHandler) skipReason(details *github.PRDetails) string {
+	for _, label := range details.PullRequest.Labels {
+		if h.isOptOutLabel(label.GetName()) {
+			return fmt.Sprintf("opted out with the %q label", label.GetName())
+		}
+	}
+	if target := details.PullRequest.GetBase().GetRef(); !h.targetBranchAllowed(target) {
+		return fmt.Sprintf("target branch %s is not in JIRA_TARGET_BRANCHES", target)
+	}
+	return h.belowSizeThreshold(details)
+}
+
+// targetBranchAllowed reports whether PRs into branch get Jira issues. Patterns use path.Match
+// syntax, so "release/*" matches release/1.2 but not release/1.2/hotfix; an empty list allows all.
+func (h *WebhookHandler) targetBranchAllowed(branch string) bool {
+	if len(h.config.JiraTargetBranches) == 0 {
+		return true
+	}
+	for _, pattern := range h.config.JiraTargetBranches {
+		if ok, _ := path.Match(pattern, branch); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// isOptOutLabel reports whether name is the configured per-PR opt-out label
+func (h *WebhookHandler) isOptOutLabel(name string) bool {
+	return h.config.JiraOptOutLabel != "" && strings.EqualFold(name, h.config.JiraOptOutLabel)
+}
+
+// handlePROptOut closes an already-tracked PR's issue when the opt-out label is added later
+func (h *WebhookHandler) handlePROptOut(prInfo jira.PRIssueInfo) {
+	if h.config.JiraOptOutStatus == "" {
+		h.logger.Info(fmt.Sprintf("PR #%d in %s labeled %q - leaving any existing Jira issue as is",
+			prInfo.PRNumber, prInfo.RepoName, h.config.JiraOptOutLabel))
+		return
+	}
+	h.handlePRTransition(prInfo, h.config.JiraOptOutStatus)
+}
+
+// belowSizeThreshold returns why a PR is too small for Jira tracking, or "" if it qualifies
+func (h *WebhookHandler) belowSizeThreshold(details *github.PRDetails) string {
+	pr := details.PullRequest
+
+	if minFiles := h.config.JiraMinChangedFiles; minFiles > 0 && pr.GetChangedFiles() < minFiles {
+		return fmt.Sprintf("%d files changed, minimum is %d", pr.GetChangedFiles(), minFiles)
+	}
+
+	lines := pr.GetAdditions() + pr.GetDeletions()
+	if minLines := h.config.JiraMinChangedLines; minLines > 0 && lines < minLines {
+		return fmt.Sprintf("%d lines changed, minimum is %d", lines, minLines)
+	}
+
+	return ""
+}
+
+// handlePRAssignment mirrors a GitHub assignee change onto the PR's Jira issue
+func (h *WebhookHandler) handlePRAssignment(prInfo jira.PRIssueInfo, login string) {
+	accountID := ""
+	if prInfo.Action == "assigned" {
+		var ok bool
+		if accountID, ok = h.jiraAccountFor("", login); !ok {
+			h.logger.Info(fmt.Sprintf("No Jira user mapping for GitHub user %s - leaving PR #%d assignee unchanged", login, prInfo.PRNumber))
+			return
+		}
+	}
+
+	issue, err := h.jiraClient.FindPRIssue(prInfo.RepoName, prInfo.PRNumber)
+	if err != nil {
+		h.logger.Error(fmt.Sprintf("Failed to find Jira issue for PR #%d: %v", prInfo.PRNumber, err))
+		h.deadLetter("set_assignee", prInfo.RepoName, prInfo.PRNumber, err)
+		return
+	}
+
+	if err := h.jiraClient.SetAssignee(issue.Key, accountID); err != nil {
+		h.logger.Error(fmt.Sprintf("Failed to update assignee on %s: %v", issue.Key, err))
+		h.deadLetter("set_assignee", prInfo.RepoName, prInfo.PRNumber, err)
+		return
+	}
+
+	if accountID == "" {
+		h.logger.Info(fmt.Sprintf("Cleared assignee on %s (%s unassigned from PR #%d)", issue.Key, login, prInfo.PRNumber))
+	} else {
+		h.logger.Info(fmt.Sprintf("Assigned %s to %s's Jira account (PR #%d)", issue.Key, login, prInfo.PRNumber))
+	}
+}
+
+// handlePRTransition moves the PR's Jira issue to the status configured for its action
+func (h *WebhookHandler) handlePRTransition(prInfo jira.PRIssueInfo, status string) {
+	h.logger.Info(fmt.Sprintf("Moving PR #%d to %s status in Jira (action: %s)", prInfo.PRNumber, status, prInfo.Action))
+
+	err := h.jiraClient.MovePRToStatus(prInfo.RepoName, prInfo.PRNumber, status)
+	if jira.IsTransitionNotAllowed(err) {
+		// Replaying would be refused again, so it isn't dead-lettered
+		h.logger.Error(fmt.Sprintf("Refused to move PR #%d to %s: %v", prInfo.PRNumber, status, err))
+		h.result.fail(err)
+		return
+	}
+	if errors.Is(err, jira.ErrIssueNotFound) && prInfo.Action == "merged" && h.config.JiraRecreateMissingOnMerge {
+		h.recreateMissingIssue(prInfo, status)
+		return
+	}
+	if err != nil {
+		h.logger.Error(fmt.Sprintf("Failed to move PR to %s: %v", status, err))
+		h.deadLetter("transition", prInfo.RepoName, prInfo.PRNumber, err)
+		return
+	}
+
+	h.stats.transitionsDone.Add(1)
+	h.logger.Info(fmt.Sprintf("Moved PR #%d to %s status successfully", prInfo.PRNumber, status))
+
+	switch prInfo.Action {
+	case "merged", "closed", "reopened":
+		h.syncRemoteLinks(prInfo)
+	}
+
+	if prInfo.Action == "merged" {
+		h.commentMergeReviewStatus(prInfo)
+	}
+}
+
+// syncRemoteLinks refreshes the PR web link on its issues, e.g. its title after an edit or its
+// resolved state after a merge; the link is cosmetic, so failures are only logged
+func (h *WebhookHandler) syncRemoteLinks(prInfo jira.PRIssueInfo) {
+	// Transitions driven by other events (deployments, checks) carry too little to render the link
+	if prInfo.PRLink == "" {
+		return
+	}
+	keys, err := h.jiraClient.SyncPRRemoteLinks(prInfo)
+	if errors.Is(err, jira.ErrIssueNotFound) {
+		return
+	}
+	if err != nil {
+		h.logger.Error(fmt.Sprintf("WARNING: failed to refresh the PR link of PR #%d in %s: %v", prInfo.PRNumber, prInfo.RepoName, err))
+		return
+	}
+	h.logger.Info(fmt.Sprintf("Refreshed the PR link on %s", strings.Join(keys, ", ")))
 }
 
 // logNewRepository logs comprehensive new repository information
@@ -349,74 +892,79 @@ func (h *WebhookHandler) logNewRepository(info github.RepoCreationInfo) {
 	h.logger.Info("=" + strings.Repeat("=", 80))
 }
 
-// logDetailedCommit logs comprehensive commit information
-func (h *WebhookHandler) logDetailedCommit(commitNum int, info github.CommitInfo) {
-	h.logger.Info(fmt.Sprintf("COMMIT #%d DETAILS:", commitNum))
-	h.logger.Info(fmt.Sprintf("  SHA: %s", info.SHA))
-	h.logger.Info(fmt.Sprintf("  Message: %s", info.Message))
-	h.logger.Info(fmt.Sprintf("  Author: %s <%s>", info.Author, info.AuthorEmail))
-	h.logger.Info(fmt.Sprintf("  Repository: %s", info.Repository))
-	h.logger.Info(fmt.Sprintf("  Branch: %s", info.Branch))
-	h.logger.Info(fmt.Sprintf("  Files Changed: %d", info.FilesChanged))
-	h.logger.Info(fmt.Sprintf("  Lines: +%d/-%d", info.Additions, info.Deletions))
-	h.logger.Info("  FILE DIFF CONTENT:")
-	h.logger.Info(strings.Repeat("-", 60))
-	h.logger.Info(info.DiffContent)
-	h.logger.Info(strings.Repeat("-", 60))
+// writeDetailedCommit appends comprehensive commit information to an event's log block
+func writeDetailedCommit(b *strings.Builder, commitNum int, info github.CommitInfo) {
+	if info.IsMerge {
+		fmt.Fprintf(b, "COMMIT #%d DETAILS (MERGE COMMIT):\n", commitNum)
+	} else {
+		fmt.Fprintf(b, "COMMIT #%d DETAILS:\n", commitNum)
+	}
+	fmt.Fprintf(b, "  SHA: %s\n", info.SHA)
+	fmt.Fprintf(b, "  Message: %s\n", info.Message)
+	fmt.Fprintf(b, "  Author: %s <%s>\n", info.Author, info.AuthorEmail)
+	fmt.Fprintf(b, "  Repository: %s\n", info.Repository)
+	fmt.Fprintf(b, "  Branch: %s\n", info.Branch)
+	fmt.Fprintf(b, "  Files Changed: %d\n", info.FilesChanged)
+	fmt.Fprintf(b, "  Lines: +%d/-%d\n", info.Additions, info.Deletions)
+	b.WriteString("  FILE DIFF CONTENT:\n")
+	b.WriteString(strings.Repeat("-", 60) + "\n")
+	b.WriteString(info.DiffContent + "\n")
+	b.WriteString(strings.Repeat("-", 60) + "\n")
 }
 
-// logDetailedPR logs comprehensive pull request information
+// logDetailedPR logs comprehensive pull request information as one block
 func (h *WebhookHandler) logDetailedPR(action string, details *github.PRDetails) {
 	pr := details.PullRequest
 
-	h.logger.Info(fmt.Sprintf("PULL REQUEST %s:", strings.ToUpper(action)))
-	h.logger.Info(fmt.Sprintf("  Title: %s", pr.GetTitle()))
-	h.logger.Info(fmt.Sprintf("  Number: #%d", pr.GetNumber()))
-	h.logger.Info(fmt.Sprintf("  Author: %s", pr.GetUser().GetLogin()))
-	h.logger.Info(fmt.Sprintf("  State: %s", pr.GetState()))
-	h.logger.Info(fmt.Sprintf("  Source Branch: %s", pr.GetHead().GetRef()))
-	h.logger.Info(fmt.Sprintf("  Target Branch: %s", pr.GetBase().GetRef()))
-	h.logger.Info(fmt.Sprintf("  Files Changed: %d", len(details.Files)))
-	h.logger.Info(fmt.Sprintf("  Reviews: %d", len(details.Reviews)))
+	var b strings.Builder
+	fmt.Fprintf(&b, "PULL REQUEST %s:\n", strings.ToUpper(action))
+	fmt.Fprintf(&b, "  Title: %s\n", pr.GetTitle())
+	fmt.Fprintf(&b, "  Number: #%d\n", pr.GetNumber())
+	fmt.Fprintf(&b, "  Author: %s\n", pr.GetUser().GetLogin())
+	fmt.Fprintf(&b, "  State: %s\n", pr.GetState())
+	fmt.Fprintf(&b, "  Source Branch: %s\n", pr.GetHead().GetRef())
+	fmt.Fprintf(&b, "  Target Branch: %s\n", pr.GetBase().GetRef())
+	fmt.Fprintf(&b, "  Files Changed: %d\n", len(details.Files))
+	if details.IgnoredFiles > 0 {
+		fmt.Fprintf(&b, "  Ignored Files: %d\n", details.IgnoredFiles)
+	}
+	fmt.Fprintf(&b, "  Reviews: %d\n", len(details.Reviews))
 
 	// Log changed files
 	if len(details.Files) > 0 {
-		h.logger.Info("  CHANGED FILES:")
+		b.WriteString("  CHANGED FILES:\n")
 		for i, file := range details.Files {
-			h.logger.Info(fmt.Sprintf("    %d. %s (+%d/-%d) [%s]",
+			fmt.Fprintf(&b, "    %d. %s (+%d/-%d) [%s]\n",
 				i+1, file.GetFilename(), file.GetAdditions(),
-				file.GetDeletions(), file.GetStatus()))
+				file.GetDeletions(), file.GetStatus())
 		}
 	}
+
+	h.logBlock(b.String())
 }
 
 // extractRepoInfo extracts comprehensive repository information
-func (h *WebhookHandler) extractRepoInfo(repo, sender map[string]interface{}) github.RepoCreationInfo {
-	name, _ := repo["name"].(string)
-	description, _ := repo["description"].(string)
-	language, _ := repo["language"].(string)
-	private, _ := repo["private"].(bool)
-	defaultBranch, _ := repo["default_branch"].(string)
-	cloneURL, _ := repo["clone_url"].(string)
-	gitURL, _ := repo["git_url"].(string)
-	sshURL, _ := repo["ssh_url"].(string)
-	createdAt, _ := repo["created_at"].(string)
-
+func extractRepoInfo(repo *gogithub.Repository, sender *gogithub.User) github.RepoCreationInfo {
 	createdBy := "Unknown"
 	if sender != nil {
-		createdBy, _ = sender["login"].(string)
+		createdBy = sender.GetLogin()
+	}
+
+	var createdAt string
+	if repo.CreatedAt != nil {
+		createdAt = repo.CreatedAt.Format(time.RFC3339)
 	}
 
 	return github.RepoCreationInfo{
-		RepoName:      name,
+		RepoName:      repo.GetName(),
 		CreatedBy:     createdBy,
 		CreatedAt:     createdAt,
-		Description:   description,
-		Language:      language,
-		Private:       private,
-		DefaultBranch: defaultBranch,
-		CloneURL:      cloneURL,
-		GitURL:        gitURL,
-		SSHURL:        sshURL,
+		Description:   repo.GetDescription(),
+		Language:      repo.GetLanguage(),
+		Private:       repo.GetPrivate(),
+		DefaultBranch: repo.GetDefaultBranch(),
+		CloneURL:      repo.GetCloneURL(),
+		GitURL:        repo.GetGitURL(),
+		SSHURL:        repo.GetSSHURL(),
 	}
 }
@@ -0,0 +1,49 @@
+package health
+
+import (
+	"sort"
+	"sync"
+)
+
+// Readiness tracks components that currently prevent the service from being ready
+type Readiness struct {
+	mu       sync.RWMutex
+	failures map[string]string
+}
+
+// NewReadiness creates a readiness tracker with no failing components
+func NewReadiness() *Readiness {
+	return &Readiness{failures: make(map[string]string)}
+}
+
+// SetUnhealthy marks a component as failing with the given reason
+func (r *Readiness) SetUnhealthy(component, reason string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.failures[component] = reason
+}
+
+// SetHealthy clears any failure recorded for a component
+func (r *Readiness) SetHealthy(component string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.failures, component)
+}
+
+// Ready reports whether all components are healthy, with failure reasons sorted by component
+func (r *Readiness) Ready() (bool, []string) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	components := make([]string, 0, len(r.failures))
+	for component := range r.failures {
+		components = append(components, component)
+	}
+	sort.Strings(components)
+
+	reasons := make([]string, 0, len(components))
+	for _, component := range components {
+		reasons = append(reasons, component+": "+r.failures[component])
+	}
+	return len(reasons) == 0, reasons
+}
@@ -0,0 +1,86 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github_integration/internal/github"
+	"github_integration/internal/notifier"
+	"github_integration/internal/utils"
+)
+
+const githubTokenComponent = "github_token"
+
+// TokenChecker periodically verifies that the GitHub token is still valid
+type TokenChecker struct {
+	githubClient *github.Client
+	readiness    *Readiness
+	notifier     notifier.Notifier
+	logger       *utils.Logger
+	interval     time.Duration
+	rejected     bool
+}
+
+// NewTokenChecker creates a checker that runs every interval
+func NewTokenChecker(githubClient *github.Client, readiness *Readiness, n notifier.Notifier, logger *utils.Logger, interval time.Duration) *TokenChecker {
+	return &TokenChecker{
+		githubClient: githubClient,
+		readiness:    readiness,
+		notifier:     n,
+		logger:       logger,
+		interval:     interval,
+	}
+}
+
+// Run checks the token immediately and then on every tick until ctx is cancelled
+func (t *TokenChecker) Run(ctx context.Context) {
+	ticker := time.NewTicker(t.interval)
+	defer ticker.Stop()
+
+	t.check()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			t.check()
+		}
+	}
+}
+
+// check performs a single validation and updates readiness accordingly
+func (t *TokenChecker) check() {
+	login, err := t.githubClient.ValidateToken()
+	if err == nil {
+		if t.rejected {
+			t.logger.Info(fmt.Sprintf("GitHub token valid again (authenticated as %s)", login))
+		}
+		t.rejected = false
+		t.readiness.SetHealthy(githubTokenComponent)
+		return
+	}
+
+	// Transient failures (network, 5xx) don't mean the credential is bad
+	if !github.IsUnauthorized(err) {
+		t.logger.Error(fmt.Sprintf("GitHub token check failed (will retry): %v", err))
+		return
+	}
+
+	// Alert once per outage rather than on every tick
+	if t.rejected {
+		return
+	}
+	t.rejected = true
+
+	t.logger.Error("=" + strings.Repeat("=", 80))
+	t.logger.Error("GITHUB TOKEN REJECTED - webhook processing will fail until GITHUB_TOKEN is replaced")
+	t.logger.Error(fmt.Sprintf("Error: %v", err))
+	t.logger.Error("=" + strings.Repeat("=", 80))
+
+	t.readiness.SetUnhealthy(githubTokenComponent, "GitHub token rejected (401)")
+	if notifyErr := t.notifier.Notify("GitHub token rejected", err.Error()); notifyErr != nil {
+		t.logger.Error(fmt.Sprintf("Failed to send token alert: %v", notifyErr))
+	}
+}
@@ -0,0 +1,33 @@
+package jira
+
+import (
+	"fmt"
+
+	"github.com/andygrunwald/go-jira"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// CreateAuditIssue files an audit-trail task (e.g. a repository visibility change) in the client's project
+func (c *Client) CreateAuditIssue(summary, description string) (*jira.Issue, error) {
+	ctx, span := c.startSpan("CreateAuditIssue", attribute.String("jira.project", c.opts.ProjectKey))
+	defer span.End()
+
+	issueData := jira.Issue{
+		Fields: &jira.IssueFields{
+			Project:     jira.Project{Key: c.opts.ProjectKey},
+			Type:        jira.IssueType{Name: "Task"},
+			Summary:     truncateRunes(summary, maxSummaryLength),
+			Description: description,
+			Labels:      []string{c.label("audit")},
+			Unknowns:    fieldDefaults(c.opts.FieldDefaults),
+		},
+	}
+
+	issue, resp, err := c.client.Issue.CreateWithContext(ctx, &issueData)
+	if err != nil {
+		err = describeCreateError(resp, err, c.opts.ProjectKey)
+		return nil, recordError(span, fmt.Errorf("failed to create audit issue in project %s: %w", c.opts.ProjectKey, err))
+	}
+	span.SetAttributes(attribute.String("jira.issue", issue.Key))
+	return issue, nil
+}
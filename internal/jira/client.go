@@ -2,35 +2,125 @@ package jira
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"strings"
+	"text/template"
 	"time"
 
 	"github.com/andygrunwald/go-jira"
+	"github.com/trivago/tgo/tcontainer"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github_integration/internal/retry"
+	"github_integration/internal/utils"
+)
+
+// Workflow statuses used by the integration
+const (
+	StatusOpenPR   = "Open_PR"
+	StatusMergedPR = "Merged_PR"
 )
 
+// ErrIssueNotFound is returned when no Jira issue is linked to a PR
+var ErrIssueNotFound = errors.New("PR issue not found")
+
 type Client struct {
-	client *jira.Client
-	ctx    context.Context
+	client     *jira.Client
+	ctx        context.Context
+	opts       Options
+	baseURL    string
+	issueTypes *issueTypeCache
+	sprints    *sprintCache
+}
+
+// Options controls where and how the integration files issues
+type Options struct {
+	// ProjectKey is the Jira project that receives PR issues
+	ProjectKey string
+	// LabelPrefix namespaces every label the integration creates (empty for none)
+	LabelPrefix string
+	// LegacyLabelLookup also matches issues labelled only "github-pr" and "pr-N", as they were
+	// before labels were prefixed and scoped to a repository
+	LegacyLabelLookup bool
+	// OpenStatus is the status new PR issues are moved to (defaults to Open_PR)
+	OpenStatus string
+	// EpicLinkField is the field holding the epic link: a custom field ID
+	// (e.g. customfield_10014) or "parent" on newer Jira. Empty disables epic linking.
+	EpicLinkField string
+	// DefaultEpic is used when the PR doesn't reference an epic
+	DefaultEpic string
+	// FieldDefaults are extra fields (e.g. required custom fields) sent on every created issue
+	FieldDefaults map[string]interface{}
+	// RoutedProjects are further projects PR issues may be created in (path-based routing);
+	// lookups and transitions search them alongside ProjectKey
+	RoutedProjects []string
+	// MaxConcurrency caps simultaneous in-flight API requests (0 means unlimited)
+	MaxConcurrency int
+	// StateMachine restricts which status moves PR issues may make (empty allows all)
+	StateMachine StateMachine
+	// UserAgent, when set, replaces the HTTP library's default User-Agent
+	UserAgent string
+	// IssueType is the default type of PR issues (defaults to Task)
+	IssueType string
+	// ProjectKeyRules, when set, file each repository's PR issues in a project derived from its name
+	ProjectKeyRules *ProjectKeyRules
+	// IncludePRBody copies the PR description (converted to Jira markup) into the issue description
+	IncludePRBody bool
+	// SprintField is the sprint custom field (e.g. customfield_10020) new PR issues are put in
+	// the active sprint of SprintBoardID through; either left empty disables sprint assignment
+	SprintField   string
+	SprintBoardID int
+	// Logger reports problems that don't fail the operation, such as a failed sprint lookup (nil discards them)
+	Logger *utils.Logger
+	// SummaryPathDepth, when positive, prefixes summaries with up to this many levels of the
+	// directory all changed files share (e.g. "[payments] PR #12: ...")
+	SummaryPathDepth int
+	// SummaryTemplate, when set, renders PR issue summaries from the PR's fields (see ParseSummaryTemplate)
+	SummaryTemplate *template.Template
+	// ClosedStatus is where CloseAllOpenForRepo moves a retired repository's open issues (defaults to Done)
+	ClosedStatus string
 }
 
 type PRIssueInfo struct {
 	PRNumber     int
 	PRTitle      string
+	PRBody       string
 	RepoName     string
 	Author       string
 	SourceBranch string
 	TargetBranch string
 	FilesChanged []string
-	PRLink       string
-	Action       string
+	// IgnoredFiles counts changed files left out of FilesChanged by the diff ignore globs
+	IgnoredFiles int
+	// ReporterAccountID, when set, makes this Jira account the issue's reporter
+	ReporterAccountID string
+	// ClosesIssues are the GitHub issue numbers the PR body closes
+	ClosesIssues []int
+	// DependsOn are the PR numbers (same repository) the PR body says it depends on
+	DependsOn []int
+	// IssueType overrides the client's default issue type (e.g. from repo topics)
+	IssueType string
+	// ExtraLabels are added to the created issue as given (sanitized, not prefixed)
+	ExtraLabels []string
+	// Components are the Jira component names set on the created issue
+	Components []string
+	PRLink     string
+	Action     string
 }
 
 // NewClient creates simple Jira API client
-func NewClient(baseURL, email, apiToken string) (*Client, error) {
+func NewClient(baseURL, email, apiToken string, opts Options) (*Client, error) {
+	// Every request goes through the concurrency limit and 429 retry handling
+	var transport http.RoundTripper = newThrottledTransport(http.DefaultTransport, opts.MaxConcurrency, retry.DefaultPolicy)
+	if opts.UserAgent != "" {
+		transport = &userAgentTransport{userAgent: opts.UserAgent, base: transport}
+	}
 	tp := jira.BasicAuthTransport{
-		Username: email,
-		Password: apiToken,
+		Username:  email,
+		Password:  apiToken,
+		Transport: transport,
 	}
 
 	client, err := jira.NewClient(tp.Client(), baseURL)
@@ -38,20 +128,84 @@ func NewClient(baseURL, email, apiToken string) (*Client, error) {
 		return nil, fmt.Errorf("failed to create Jira client: %w", err)
 	}
 
+	if opts.OpenStatus == "" {
+		opts.OpenStatus = StatusOpenPR
+	}
+
 	return &Client{
-		client: client,
-		ctx:    context.Background(),
+		client:     client,
+		ctx:        context.Background(),
+		opts:       opts,
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		issueTypes: &issueTypeCache{projects: make(map[string][]string)},
+		sprints:    &sprintCache{},
 	}, nil
 }
 
-// Simple project key generation: repo-name → REPO-NAME
-func (c *Client) getProjectKey(repoName string) string {
-	return strings.ToUpper(repoName)
+// IssueURL returns the browser link for an issue
+func (c *Client) IssueURL(issueKey string) string {
+	return fmt.Sprintf("%s/browse/%s", c.baseURL, issueKey)
+}
+
+// Options returns the settings the client files issues with
+func (c *Client) Options() Options {
+	return c.opts
 }
 
-// CreatePRIssue creates new issue in Open_PR status
+// WithOptions returns a copy of the client that files issues with opts
+func (c *Client) WithOptions(opts Options) *Client {
+	if opts.OpenStatus == "" {
+		opts.OpenStatus = StatusOpenPR
+	}
+	clone := *c
+	clone.opts = opts
+	return &clone
+}
+
+// InProject returns a copy of the client that creates issues in projectKey
+func (c *Client) InProject(projectKey string) *Client {
+	if projectKey == "" || projectKey == c.opts.ProjectKey {
+		return c
+	}
+	opts := c.opts
+	opts.ProjectKey = projectKey
+	return c.WithOptions(opts)
+}
+
+// projectClause is the JQL restricting searches to every project the repositories' PR issues may live in
+func (c *Client) projectClause(repoNames ...string) string {
+	candidates := append([]string{c.opts.ProjectKey}, c.opts.RoutedProjects...)
+	for _, repoName := range repoNames {
+		if key, ok := c.getProjectKey(repoName); ok {
+			candidates = append(candidates, key)
+		}
+	}
+
+	seen := make(map[string]bool)
+	var projects []string
+	for _, project := range candidates {
+		if !seen[project] {
+			seen[project] = true
+			projects = append(projects, fmt.Sprintf("%q", project))
+		}
+	}
+	if len(projects) == 1 {
+		return "project = " + projects[0]
+	}
+	return fmt.Sprintf("project in (%s)", strings.Join(projects, ", "))
+}
+
+// OpenStatus returns the status newly created PR issues are moved to
+func (c *Client) OpenStatus() string {
+	return c.opts.OpenStatus
+}
+
+// CreatePRIssue creates new issue in the open status (Open_PR by default)
 func (c *Client) CreatePRIssue(prInfo PRIssueInfo) (*jira.Issue, error) {
-	projectKey := "REP"
+	ctx, span := c.startSpan("CreatePRIssue", attribute.String("github.repo", prInfo.RepoName), attribute.Int("github.pr", prInfo.PRNumber))
+	defer span.End()
+
+	projectKey := c.opts.ProjectKey
 
 	// Build simple description
 	description := fmt.Sprintf(`
@@ -61,94 +215,487 @@ func (c *Client) CreatePRIssue(prInfo PRIssueInfo) (*jira.Issue, error) {
 • Author: %s
 • Source Branch: %s → Target Branch: %s
 • PR Link: [View on GitHub|%s]
-
+%s%s
 *Files Changed:*
 %s
-
+%s
 _Created: %s_
 `, prInfo.RepoName, prInfo.PRNumber, prInfo.Author,
 		prInfo.SourceBranch, prInfo.TargetBranch, prInfo.PRLink,
+		closesIssuesLine(prInfo),
+		c.prBodySection(prInfo),
 		strings.Join(prInfo.FilesChanged, "\n• "),
+		ignoredFilesNote(prInfo.IgnoredFiles),
 		time.Now().Format("2006-01-02 15:04:05"))
 
-	// Create issue in
-	//issue created
-	//issue added
-	//issue
+	// Create issue in the project
 	issueData := jira.Issue{
 		Fields: &jira.IssueFields{
 			Project: jira.Project{
 				Key: projectKey,
 			},
 			Type: jira.IssueType{
-				Name: "Task",
-				Description: 
-			},
-			Summary:     fmt.Sprintf("PR #%d, prInfo.PRNumber, prInfo.PRTitle),
-			Description: descriptio
-			Labels: []strin
-				"github-pr"
-				fmt.Sprintf("pr-%d", prInfo.PRNumber),
+				Name: c.issueType(prInfo),
 			},
+			Summary:     c.prSummary(prInfo),
+			Description: description,
+			Labels:      mergeLabels(c.prLabels(prInfo.RepoName, prInfo.PRNumber), prInfo.ExtraLabels),
+			Unknowns:    fieldDefaults(c.opts.FieldDefaults),
 		},
 	}
+	for _, component := range prInfo.Components {
+		issueData.Fields.Components = append(issueData.Fields.Components, &jira.Component{Name: component})
+	}
+	if prInfo.ReporterAccountID != "" {
+		issueData.Fields.Reporter = &jira.User{AccountID: prInfo.ReporterAccountID}
+	}
+	c.applyEpic(issueData.Fields, c.resolveEpic(prInfo))
+	c.WithContext(ctx).applySprint(issueData.Fields)
 
-	issue, _, err := c.client.Issue.Create(&issueData)
+	issue, resp, err := c.client.Issue.CreateWithContext(ctx, &issueData)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create issue in project %s: %w", projectKey, err)
+		err = describeCreateError(resp, err, projectKey)
+		return nil, recordError(span, fmt.Errorf("failed to create issue in project %s: %w", projectKey, err))
+	}
+	span.SetAttributes(attribute.String("jira.issue", issue.Key))
+
+	// Surface the PR as a proper web link; like the status move, failures don't undo the issue
+	scoped := c.WithContext(ctx)
+	if err := scoped.UpsertPRRemoteLink(issue.Key, prInfo); err != nil {
+		span.RecordError(err)
 	}
 
-	// Move to Open_PR status if not already
-	c.moveToStatus(issue.Key, "Open_PR")
+	// Move to the open status if not already
+	scoped.moveToStatus(issue.Key, c.opts.OpenStatus)
 
 	return issue, nil
 }
 
+// closesIssuesLine lists the GitHub issues the PR closes, linked next to the PR
+func closesIssuesLine(prInfo PRIssueInfo) string {
+	if len(prInfo.ClosesIssues) == 0 {
+		return ""
+	}
+
+	// Issue URLs share the PR link's repository prefix
+	repoURL, _, _ := strings.Cut(prInfo.PRLink, "/pull/")
+	refs := make([]string, len(prInfo.ClosesIssues))
+	for i, number := range prInfo.ClosesIssues {
+		refs[i] = fmt.Sprintf("[#%d|%s/issues/%d]", number, repoURL, number)
+	}
+	return fmt.Sprintf("• Closes: %s\n", strings.Join(refs, ", "))
+}
+
+// ignoredFilesNote mentions files left out of the description's file list, if any
+func ignoredFilesNote(ignored int) string {
+	if ignored == 0 {
+		return ""
+	}
+	return fmt.Sprintf("_(%d generated/vendored files not listed)_\n", ignored)
+}
+
+// fieldDefaults copies the configured default fields so per-issue fields don't leak between issues
+func fieldDefaults(defaults map[string]interface{}) tcontainer.MarshalMap {
+	fields := tcontainer.NewMarshalMap()
+	for key, value := range defaults {
+		fields[key] = value
+	}
+	return fields
+}
+
 // FindPRIssue finds existing PR issue
 func (c *Client) FindPRIssue(repoName string, prNumber int) (*jira.Issue, error) {
-	projectKey := "REP"
+	ctx, span := c.startSpan("FindPRIssue", attribute.String("github.repo", repoName), attribute.Int("github.pr", prNumber))
+	defer span.End()
+
+	issues, err := c.WithContext(ctx).searchPRIssues(repoName, prNumber, 1)
+	if err != nil {
+		return nil, recordError(span, err)
+	}
+	return &issues[0], nil
+}
+
+// FindPRIssues finds every issue tracking a PR (one per project when routing creates several)
+func (c *Client) FindPRIssues(repoName string, prNumber int) ([]jira.Issue, error) {
+	ctx, span := c.startSpan("FindPRIssues", attribute.String("github.repo", repoName), attribute.Int("github.pr", prNumber))
+	defer span.End()
+
+	issues, err := c.WithContext(ctx).searchPRIssues(repoName, prNumber, 50)
+	if err != nil {
+		return nil, recordError(span, err)
+	}
+	return issues, nil
+}
 
-	jql := fmt.Sprintf(`project = "%s" AND labels = "pr-%d"`, projectKey, prNumber)
+// searchPRIssues runs the PR issue lookup, returning ErrIssueNotFound when nothing matches
+func (c *Client) searchPRIssues(repoName string, prNumber, maxResults int) ([]jira.Issue, error) {
+	jql := fmt.Sprintf(`%s AND %s`, c.projectClause(repoName), c.prIssueClause(repoName, prNumber))
 
-	issues, _, err := c.client.Issue.Search(jql, &jira.SearchOptions{
-		MaxResults: 1,
+	issues, _, err := c.client.Issue.SearchWithContext(c.ctx, jql, &jira.SearchOptions{
+		MaxResults: maxResults,
 	})
 	if err != nil {
 		return nil, err
 	}
-
 	if len(issues) == 0 {
-		return nil, fmt.Errorf("PR issue not found")
+		return nil, ErrIssueNotFound
 	}
+	return issues, nil
+}
 
-	return &issues[0], nil
+// CountResolvedSince counts the integration's PR issues resolved at or after since
+func (c *Client) CountResolvedSince(since time.Time) (int, error) {
+	ctx, span := c.startSpan("CountResolvedSince")
+	defer span.End()
+
+	jql := fmt.Sprintf(`%s AND labels = "%s" AND resolved >= "%s"`, c.projectClause(), c.label("pr"), since.Format("2006/01/02 15:04"))
+	_, resp, err := c.client.Issue.SearchWithContext(ctx, jql, &jira.SearchOptions{MaxResults: 1, Fields: []string{"key"}})
+	if err != nil {
+		return 0, recordError(span, fmt.Errorf("failed to count resolved issues: %w", err))
+	}
+	return resp.Total, nil
+}
+
+// SyncPRLabel adds or removes the Jira counterpart of a GitHub label on the PR's issues,
+// returning the updated issue keys
+func (c *Client) SyncPRLabel(repoName string, prNumber int, label string, add bool) ([]string, error) {
+	ctx, span := c.startSpan("SyncPRLabel", attribute.String("github.repo", repoName), attribute.Int("github.pr", prNumber))
+	defer span.End()
+
+	issues, err := c.WithContext(ctx).searchPRIssues(repoName, prNumber, 50)
+	if err != nil {
+		return nil, recordError(span, err)
+	}
+
+	op := "remove"
+	if add {
+		op = "add"
+	}
+	keys, err := c.updateLabels(ctx, issues, []map[string]string{{op: c.githubLabel(label)}})
+	if err != nil {
+		return keys, recordError(span, fmt.Errorf("failed to %s label %q for PR #%d: %w", op, label, prNumber, err))
+	}
+	return keys, nil
+}
+
+// updateLabels applies label add/remove operations to each issue, returning the keys updated
+func (c *Client) updateLabels(ctx context.Context, issues []jira.Issue, ops []map[string]string) ([]string, error) {
+	update := map[string]interface{}{
+		"update": map[string]interface{}{"labels": ops},
+	}
+	var keys []string
+	for _, issue := range issues {
+		if _, err := c.client.Issue.UpdateIssueWithContext(ctx, issue.Key, update); err != nil {
+			return keys, fmt.Errorf("%s: %w", issue.Key, err)
+		}
+		keys = append(keys, issue.Key)
+	}
+	return keys, nil
+}
+
+// maxRenamePages bounds the search-and-relabel rounds of RenameRepo (50 issues each)
+const maxRenamePages = 100
+
+// RenameRepo relabels every issue of a renamed repository so PR lookups under the new name find them,
+// returning the relabelled issue keys
+func (c *Client) RenameRepo(oldName, newName string) ([]string, error) {
+	ctx, span := c.startSpan("RenameRepo", attribute.String("github.repo", newName), attribute.String("github.repo_old", oldName))
+	defer span.End()
+
+	oldLabel, newLabel := c.repoLabel(oldName), c.repoLabel(newName)
+	if oldLabel == newLabel {
+		return nil, nil
+	}
+	jql := fmt.Sprintf(`%s AND labels = "%s"`, c.projectClause(oldName, newName), oldLabel)
+
+	// Relabelled issues drop out of the search, so keep taking the first page until it is empty.
+	// The pages are bounded in case Jira accepts an update without removing the label.
+	var keys []string
+	for page := 0; page < maxRenamePages; page++ {
+		issues, _, err := c.client.Issue.SearchWithContext(ctx, jql, &jira.SearchOptions{MaxResults: 50})
+		if err != nil {
+			return keys, recordError(span, fmt.Errorf("failed to search issues of %s: %w", oldName, err))
+		}
+		if len(issues) == 0 {
+			return keys, nil
+		}
+		updated, err := c.updateLabels(ctx, issues, []map[string]string{{"add": newLabel}, {"remove": oldLabel}})
+		keys = append(keys, updated...)
+		if err != nil {
+			return keys, recordError(span, fmt.Errorf("failed to relabel %s as %s: %w", oldName, newName, err))
+		}
+	}
+	return keys, recordError(span, fmt.Errorf("issues of %s still carry %s after %d pages of relabelling", oldName, oldLabel, maxRenamePages))
+}
+
+// CloseAllOpenForRepo moves every open PR issue of a repository to the closed status, returning
+// how many were closed. Issues already done (status category Done) are left alone.
+func (c *Client) CloseAllOpenForRepo(repoName string) (int, error) {
+	ctx, span := c.startSpan("CloseAllOpenForRepo", attribute.String("github.repo", repoName))
+	defer span.End()
+
+	closedStatus := c.opts.ClosedStatus
+	if closedStatus == "" {
+		closedStatus = "Done"
+	}
+	jql := fmt.Sprintf(`%s AND labels = "%s" AND labels = "%s" AND statusCategory != Done AND status != "%s"`,
+		c.projectClause(repoName), c.label("pr"), c.repoLabel(repoName), closedStatus)
+
+	// Collect every page first: moving issues while paging would shift the result window
+	var keys []string
+	for startAt := 0; ; {
+		issues, resp, err := c.client.Issue.SearchWithContext(ctx, jql, &jira.SearchOptions{StartAt: startAt, MaxResults: 50, Fields: []string{"key"}})
+		if err != nil {
+			return 0, recordError(span, fmt.Errorf("failed to search open issues of %s: %w", repoName, err))
+		}
+		for _, issue := range issues {
+			keys = append(keys, issue.Key)
+		}
+		startAt += len(issues)
+		if len(issues) == 0 || resp == nil || startAt >= resp.Total {
+			break
+		}
+	}
+
+	scoped := c.WithContext(ctx)
+	closed := 0
+	var failed []string
+	for _, key := range keys {
+		if err := scoped.moveToStatus(key, closedStatus); err != nil {
+			failed = append(failed, key)
+			continue
+		}
+		closed++
+	}
+	if len(failed) > 0 {
+		return closed, recordError(span, fmt.Errorf("failed to move %s to %s", strings.Join(failed, ", "), closedStatus))
+	}
+	return closed, nil
+}
+
+// DetachPRIssues swaps the PR number label on the PR's issues for a detached marker so the
+// integration stops tracking them, returning the detached issue keys
+func (c *Client) DetachPRIssues(repoName string, prNumber int) ([]string, error) {
+	ctx, span := c.startSpan("DetachPRIssues", attribute.String("github.repo", repoName), attribute.Int("github.pr", prNumber))
+	defer span.End()
+
+	issues, err := c.WithContext(ctx).searchPRIssues(repoName, prNumber, 50)
+	if err != nil {
+		return nil, recordError(span, err)
+	}
+
+	ops := []map[string]string{{"remove": c.prNumberLabel(prNumber)}, {"add": c.detachedLabel(prNumber)}}
+	if c.opts.LegacyLabelLookup {
+		// Legacy issues lack the repo label IsPRDetached looks for
+		ops = append(ops, map[string]string{"remove": fmt.Sprintf("pr-%d", prNumber)}, map[string]string{"add": c.repoLabel(repoName)})
+	}
+	keys, err := c.updateLabels(ctx, issues, ops)
+	if err != nil {
+		return keys, recordError(span, fmt.Errorf("failed to detach from PR #%d: %w", prNumber, err))
+	}
+	return keys, nil
+}
+
+// IsPRDetached reports whether the PR's issues were detached with DetachPRIssues
+func (c *Client) IsPRDetached(repoName string, prNumber int) (bool, error) {
+	ctx, span := c.startSpan("IsPRDetached", attribute.String("github.repo", repoName), attribute.Int("github.pr", prNumber))
+	defer span.End()
+
+	jql := fmt.Sprintf(`%s AND labels = "%s" AND labels = "%s"`,
+		c.projectClause(repoName), c.detachedLabel(prNumber), c.repoLabel(repoName))
+	issues, _, err := c.client.Issue.SearchWithContext(ctx, jql, &jira.SearchOptions{MaxResults: 1})
+	if err != nil {
+		return false, recordError(span, err)
+	}
+	return len(issues) > 0, nil
 }
 
 // MovePRToMerged moves PR issue to Merged_PR status
 func (c *Client) MovePRToMerged(repoName string, prNumber int) error {
-	issue, err := c.FindPRIssue(repoName, prNumber)
+	return c.MovePRToStatus(repoName, prNumber, StatusMergedPR)
+}
+
+// MovePRToOpen moves PR issue back to the open status
+func (c *Client) MovePRToOpen(repoName string, prNumber int) error {
+	return c.MovePRToStatus(repoName, prNumber, c.opts.OpenStatus)
+}
+
+// MovePRToStatus moves the PR's issue to an arbitrary workflow status
+func (c *Client) MovePRToStatus(repoName string, prNumber int, status string) error {
+	ctx, span := c.startSpan("MovePRToStatus", attribute.String("github.repo", repoName),
+		attribute.Int("github.pr", prNumber), attribute.String("jira.status", status))
+	defer span.End()
+
+	scoped := c.WithContext(ctx)
+	issues, err := scoped.FindPRIssues(repoName, prNumber)
 	if err != nil {
-		return err
+		return recordError(span, err)
 	}
 
-	return c.moveToStatus(issue.Key, "Merged_PR")
+	var errs []error
+	for _, issue := range issues {
+		if current := issueStatus(issue); !c.opts.StateMachine.Allows(current, status) {
+			errs = append(errs, &TransitionNotAllowedError{IssueKey: issue.Key, From: current, To: status})
+			continue
+		}
+		if err := scoped.moveToStatus(issue.Key, status); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", issue.Key, err))
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return recordError(span, err)
+	}
+	return nil
+}
+
+// issueStatus returns the name of an issue's current status, or "" when the search omitted it
+func issueStatus(issue jira.Issue) string {
+	if issue.Fields == nil || issue.Fields.Status == nil {
+		return ""
+	}
+	return issue.Fields.Status.Name
 }
 
 // moveToStatus transitions issue to target status
 func (c *Client) moveToStatus(issueKey, targetStatus string) error {
+	ctx, span := c.startSpan("moveToStatus", attribute.String("jira.issue", issueKey), attribute.String("jira.status", targetStatus))
+	defer span.End()
+
 	// Get available transitions
-	transitions, _, err := c.client.Issue.GetTransitions(issueKey)
+	transitions, _, err := c.client.Issue.GetTransitionsWithContext(ctx, issueKey)
 	if err != nil {
-		return err
+		return recordError(span, err)
 	}
 
-	// Find transition to target status
+	transitionID, ok := findTransition(transitions, targetStatus)
+	if !ok {
+		return recordError(span, fmt.Errorf("no transition found to status: %s", targetStatus))
+	}
+	if _, err = c.client.Issue.DoTransitionWithContext(ctx, issueKey, transitionID); err != nil {
+		return recordError(span, err)
+	}
+	return nil
+}
+
+// findTransition returns the ID of the first transition leading to targetStatus
+func findTransition(transitions []jira.Transition, targetStatus string) (string, bool) {
 	for _, transition := range transitions {
 		if transition.To.Name == targetStatus {
-			_, err = c.client.Issue.DoTransition(issueKey, transition.ID)
-			return err
+			return transition.ID, true
+		}
+	}
+	return "", false
+}
+
+// ProjectStatuses lists every workflow status name used by the project's issue types
+func (c *Client) ProjectStatuses() ([]string, error) {
+	ctx, span := c.startSpan("ProjectStatuses")
+	defer span.End()
+
+	req, err := c.client.NewRequestWithContext(ctx, "GET", fmt.Sprintf("rest/api/2/project/%s/statuses", c.opts.ProjectKey), nil)
+	if err != nil {
+		return nil, recordError(span, err)
+	}
+
+	var issueTypes []struct {
+		Statuses []struct {
+			Name string `json:"name"`
+		} `json:"statuses"`
+	}
+	if err := c.do(req, &issueTypes); err != nil {
+		return nil, recordError(span, fmt.Errorf("failed to get statuses for project %s: %w", c.opts.ProjectKey, err))
+	}
+
+	seen := make(map[string]bool)
+	var statuses []string
+	for _, issueType := range issueTypes {
+		for _, status := range issueType.Statuses {
+			if !seen[status.Name] {
+				seen[status.Name] = true
+				statuses = append(statuses, status.Name)
+			}
+		}
+	}
+	return statuses, nil
+}
+
+// SampleTransitions returns the transition targets available from a representative issue,
+// preferring an integration issue currently in the open status
+func (c *Client) SampleTransitions() (string, []string, error) {
+	ctx, span := c.startSpan("SampleTransitions")
+	defer span.End()
+
+	queries := []string{
+		fmt.Sprintf(`project = "%s" AND labels = "%s" AND status = "%s" ORDER BY created DESC`,
+			c.opts.ProjectKey, c.label("pr"), c.opts.OpenStatus),
+		fmt.Sprintf(`project = "%s" ORDER BY created DESC`, c.opts.ProjectKey),
+	}
+
+	for _, jql := range queries {
+		issues, _, err := c.client.Issue.SearchWithContext(ctx, jql, &jira.SearchOptions{MaxResults: 1})
+		if err != nil {
+			return "", nil, recordError(span, fmt.Errorf("failed to find a sample issue: %w", err))
+		}
+		if len(issues) == 0 {
+			continue
 		}
+
+		transitions, _, err := c.client.Issue.GetTransitionsWithContext(ctx, issues[0].Key)
+		if err != nil {
+			return "", nil, recordError(span, fmt.Errorf("failed to get transitions for %s: %w", issues[0].Key, err))
+		}
+
+		targets := make([]string, 0, len(transitions))
+		for _, transition := range transitions {
+			targets = append(targets, transition.To.Name)
+		}
+		return issues[0].Key, targets, nil
 	}
 
-	return fmt.Errorf("no transition found to status: %s", targetStatus)
+	return "", nil, recordError(span, fmt.Errorf("project %s has no issues to sample transitions from", c.opts.ProjectKey))
+}
+
+// AddComment posts a comment on an issue
+func (c *Client) AddComment(issueKey, body string) error {
+	ctx, span := c.startSpan("AddComment", attribute.String("jira.issue", issueKey))
+	defer span.End()
+
+	if _, _, err := c.client.Issue.AddCommentWithContext(ctx, issueKey, &jira.Comment{Body: body}); err != nil {
+		return recordError(span, fmt.Errorf("failed to add comment to %s: %w", issueKey, err))
+	}
+	return nil
+}
+
+// SetAssignee assigns an issue to a Jira account, or clears the assignee when accountID is empty
+func (c *Client) SetAssignee(issueKey string, accountID string) error {
+	ctx, span := c.startSpan("SetAssignee", attribute.String("jira.issue", issueKey))
+	defer span.End()
+
+	// A null accountId unassigns the issue
+	var assignee interface{}
+	if accountID != "" {
+		assignee = accountID
+	}
+
+	req, err := c.client.NewRequestWithContext(ctx, "PUT", fmt.Sprintf("rest/api/2/issue/%s/assignee", issueKey),
+		map[string]interface{}{"accountId": assignee})
+	if err != nil {
+		return recordError(span, err)
+	}
+	if err := c.do(req, nil); err != nil {
+		return recordError(span, fmt.Errorf("failed to set assignee on %s: %w", issueKey, err))
+	}
+	return nil
+}
+
+// do sends a raw API request, decoding into v when non-nil and turning failures into Jira errors
+func (c *Client) do(req *http.Request, v interface{}) error {
+	resp, err := c.client.Do(req, v)
+	if err != nil {
+		return jira.NewJiraError(resp, err)
+	}
+	if v == nil {
+		resp.Body.Close()
+	}
+	return nil
 }
@@ -0,0 +1,90 @@
+package jira
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMoveToStatus(t *testing.T) {
+	tests := []struct {
+		name        string
+		transitions string
+		status      int
+		target      string
+		wantID      string
+		wantErr     bool
+	}{
+		{
+			name:        "exact match",
+			transitions: `{"transitions":[{"id":"11","to":{"name":"In Review"}},{"id":"21","to":{"name":"Merged_PR"}}]}`,
+			target:      "Merged_PR",
+			wantID:      "21",
+		},
+		{
+			name:        "no matching transition",
+			transitions: `{"transitions":[{"id":"11","to":{"name":"In Review"}}]}`,
+			target:      "Merged_PR",
+			wantErr:     true,
+		},
+		{
+			name:        "duplicate target names take the first",
+			transitions: `{"transitions":[{"id":"31","to":{"name":"Done"}},{"id":"41","to":{"name":"Done"}}]}`,
+			target:      "Done",
+			wantID:      "31",
+		},
+		{
+			name:    "transitions API error",
+			status:  http.StatusInternalServerError,
+			target:  "Done",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var posted string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/rest/api/2/issue/PROJ-1/transitions" {
+					http.NotFound(w, r)
+					return
+				}
+				switch r.Method {
+				case http.MethodGet:
+					if tt.status != 0 {
+						w.WriteHeader(tt.status)
+						return
+					}
+					w.Header().Set("Content-Type", "application/json")
+					w.Write([]byte(tt.transitions))
+				case http.MethodPost:
+					var body struct {
+						Transition struct {
+							ID string `json:"id"`
+						} `json:"transition"`
+					}
+					if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+						t.Errorf("decode transition request: %v", err)
+					}
+					posted = body.Transition.ID
+					w.WriteHeader(http.StatusNoContent)
+				}
+			}))
+			defer server.Close()
+
+			client, err := NewClient(server.URL, "user", "token", Options{ProjectKey: "PROJ"})
+			if err != nil {
+				t.Fatalf("NewClient: %v", err)
+			}
+
+			err = client.moveToStatus("PROJ-1", tt.target)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("moveToStatus error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if posted != tt.wantID {
+				t.Errorf("posted transition %q, want %q", posted, tt.wantID)
+			}
+		})
+	}
+}
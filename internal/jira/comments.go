@@ -0,0 +1,93 @@
+package jira
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/andygrunwald/go-jira"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// commentPageSize is how many comments are read per request when scanning an issue's comments
+const commentPageSize = 100
+
+// UpsertMarkedComment keeps a single living comment carrying marker on an issue: the newest
+// comment with the marker is updated to body (appending the marker if body lacks it), or a new
+// comment is added when none has it. Older duplicates left by earlier races are deleted.
+func (c *Client) UpsertMarkedComment(issueKey, marker, body string) error {
+	ctx, span := c.startSpan("UpsertMarkedComment", attribute.String("jira.issue", issueKey))
+	defer span.End()
+
+	if !strings.Contains(body, marker) {
+		body += marker
+	}
+
+	comments, err := c.WithContext(ctx).listComments(issueKey)
+	if err != nil {
+		return recordError(span, err)
+	}
+	var marked []*jira.Comment
+	for _, comment := range comments {
+		if strings.Contains(comment.Body, marker) {
+			marked = append(marked, comment)
+		}
+	}
+
+	if len(marked) == 0 {
+		if _, _, err := c.client.Issue.AddCommentWithContext(ctx, issueKey, &jira.Comment{Body: body}); err != nil {
+			return recordError(span, fmt.Errorf("failed to add comment to %s: %w", issueKey, err))
+		}
+		return nil
+	}
+
+	// Comments are listed oldest first
+	latest := marked[len(marked)-1]
+	for _, duplicate := range marked[:len(marked)-1] {
+		if err := c.client.Issue.DeleteCommentWithContext(ctx, issueKey, duplicate.ID); err != nil {
+			span.RecordError(fmt.Errorf("failed to delete duplicate comment %s on %s: %w", duplicate.ID, issueKey, err))
+		}
+	}
+	if latest.Body == body {
+		return nil
+	}
+	_, resp, err := c.client.Issue.UpdateCommentWithContext(ctx, issueKey, &jira.Comment{ID: latest.ID, Body: body})
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		// Deleted since it was listed: start a new one
+		_, _, err = c.client.Issue.AddCommentWithContext(ctx, issueKey, &jira.Comment{Body: body})
+	}
+	if err != nil {
+		return recordError(span, fmt.Errorf("failed to update comment %s on %s: %w", latest.ID, issueKey, err))
+	}
+	return nil
+}
+
+// listComments returns every comment on an issue, oldest first
+func (c *Client) listComments(issueKey string) ([]*jira.Comment, error) {
+	ctx, span := c.startSpan("listComments", attribute.String("jira.issue", issueKey))
+	defer span.End()
+
+	var all []*jira.Comment
+	for startAt := 0; ; {
+		endpoint := fmt.Sprintf("rest/api/2/issue/%s/comment?startAt=%d&maxResults=%d&orderBy=created", issueKey, startAt, commentPageSize)
+		req, err := c.client.NewRequestWithContext(ctx, "GET", endpoint, nil)
+		if err != nil {
+			return nil, recordError(span, err)
+		}
+
+		var page struct {
+			StartAt  int             `json:"startAt"`
+			Total    int             `json:"total"`
+			Comments []*jira.Comment `json:"comments"`
+		}
+		if err := c.do(req, &page); err != nil {
+			return nil, recordError(span, fmt.Errorf("failed to list comments on %s: %w", issueKey, err))
+		}
+		all = append(all, page.Comments...)
+
+		startAt += len(page.Comments)
+		if len(page.Comments) == 0 || startAt >= page.Total {
+			return all, nil
+		}
+	}
+}
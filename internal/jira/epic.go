@@ -0,0 +1,52 @@
+package jira
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/andygrunwald/go-jira"
+	"github.com/trivago/tgo/tcontainer"
+)
+
+// epicLinkParent selects the parent field used by team-managed and newer Jira projects
+const epicLinkParent = "parent"
+
+// epicReference matches an explicit "Epic: KEY-123" reference in PR text
+var epicReference = regexp.MustCompile(`(?i)\bepic:?\s*\[?([A-Z][A-Z0-9_]+-\d+)\]?`)
+
+// ParseEpicKey extracts an epic key referenced as "Epic: KEY-123" from PR text
+func ParseEpicKey(text string) string {
+	match := epicReference.FindStringSubmatch(text)
+	if match == nil {
+		return ""
+	}
+	return strings.ToUpper(match[1])
+}
+
+// resolveEpic picks the epic for a PR: the PR title, then body, then the configured default
+func (c *Client) resolveEpic(prInfo PRIssueInfo) string {
+	if key := ParseEpicKey(prInfo.PRTitle); key != "" {
+		return key
+	}
+	if key := ParseEpicKey(prInfo.PRBody); key != "" {
+		return key
+	}
+	return c.opts.DefaultEpic
+}
+
+// applyEpic links the issue fields to epicKey through the configured epic link field
+func (c *Client) applyEpic(fields *jira.IssueFields, epicKey string) {
+	if epicKey == "" || c.opts.EpicLinkField == "" {
+		return
+	}
+
+	if c.opts.EpicLinkField == epicLinkParent {
+		fields.Parent = &jira.Parent{Key: epicKey}
+		return
+	}
+
+	if fields.Unknowns == nil {
+		fields.Unknowns = tcontainer.NewMarshalMap()
+	}
+	fields.Unknowns[c.opts.EpicLinkField] = epicKey
+}
@@ -0,0 +1,57 @@
+package jira
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/andygrunwald/go-jira"
+)
+
+// FieldErrorsError reports the fields Jira rejected (typically required fields left empty)
+type FieldErrorsError struct {
+	ProjectKey string
+	Fields     map[string]string
+}
+
+func (e *FieldErrorsError) Error() string {
+	names := make([]string, 0, len(e.Fields))
+	for name := range e.Fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	problems := make([]string, 0, len(names))
+	for _, name := range names {
+		problems = append(problems, fmt.Sprintf("%s (%s)", name, e.Fields[name]))
+	}
+	return fmt.Sprintf("Jira rejected the issue fields for project %s: %s - set them via JIRA_FIELD_DEFAULTS",
+		e.ProjectKey, strings.Join(problems, ", "))
+}
+
+// describeCreateError turns a Jira 400 response into a FieldErrorsError naming each offending field
+func describeCreateError(resp *jira.Response, err error, projectKey string) error {
+	if resp == nil || resp.StatusCode != http.StatusBadRequest {
+		return err
+	}
+
+	var jiraErr *jira.Error
+	if !errors.As(jira.NewJiraError(resp, err), &jiraErr) || len(jiraErr.Errors) == 0 {
+		return err
+	}
+
+	return &FieldErrorsError{ProjectKey: projectKey, Fields: jiraErr.Errors}
+}
+
+// IsFieldRejected reports whether err is Jira rejecting field (e.g. "reporter" without the
+// Modify Reporter permission)
+func IsFieldRejected(err error, field string) bool {
+	var fieldErr *FieldErrorsError
+	if !errors.As(err, &fieldErr) {
+		return false
+	}
+	_, ok := fieldErr.Fields[field]
+	return ok
+}
@@ -0,0 +1,72 @@
+package jira
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// DefaultIssueType is used when neither the options nor the PR choose an issue type
+const DefaultIssueType = "Task"
+
+// issueTypeCache remembers each project's issue type names; it is shared by client copies
+type issueTypeCache struct {
+	mu       sync.Mutex
+	projects map[string][]string
+}
+
+// issueType returns the type a PR issue is created as
+func (c *Client) issueType(prInfo PRIssueInfo) string {
+	if prInfo.IssueType != "" {
+		return prInfo.IssueType
+	}
+	if c.opts.IssueType != "" {
+		return c.opts.IssueType
+	}
+	return DefaultIssueType
+}
+
+// ProjectIssueTypes lists the issue type names available in the client's project (cached)
+func (c *Client) ProjectIssueTypes() ([]string, error) {
+	projectKey := c.opts.ProjectKey
+
+	c.issueTypes.mu.Lock()
+	names, ok := c.issueTypes.projects[projectKey]
+	c.issueTypes.mu.Unlock()
+	if ok {
+		return names, nil
+	}
+
+	ctx, span := c.startSpan("ProjectIssueTypes", attribute.String("jira.project", projectKey))
+	defer span.End()
+
+	project, _, err := c.client.Project.GetWithContext(ctx, projectKey)
+	if err != nil {
+		return nil, recordError(span, fmt.Errorf("failed to get issue types for project %s: %w", projectKey, err))
+	}
+	names = make([]string, 0, len(project.IssueTypes))
+	for _, issueType := range project.IssueTypes {
+		names = append(names, issueType.Name)
+	}
+
+	c.issueTypes.mu.Lock()
+	c.issueTypes.projects[projectKey] = names
+	c.issueTypes.mu.Unlock()
+	return names, nil
+}
+
+// ResolveIssueType returns the project's spelling of issueType, or false if the project has no such type
+func (c *Client) ResolveIssueType(issueType string) (string, bool, error) {
+	names, err := c.ProjectIssueTypes()
+	if err != nil {
+		return "", false, err
+	}
+	for _, name := range names {
+		if strings.EqualFold(name, issueType) {
+			return name, true, nil
+		}
+	}
+	return "", false, nil
+}
@@ -0,0 +1,93 @@
+package jira
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// maxLabelLength is Jira's limit on a single label
+const maxLabelLength = 255
+
+// invalidLabelChars matches anything Jira rejects or that breaks JQL quoting in a label
+var invalidLabelChars = regexp.MustCompile(`[^A-Za-z0-9_.:\-]+`)
+
+// SanitizeLabel converts arbitrary text into a label Jira accepts
+func SanitizeLabel(label string) string {
+	label = invalidLabelChars.ReplaceAllString(strings.TrimSpace(label), "-")
+	label = strings.Trim(label, "-")
+	if len(label) > maxLabelLength {
+		label = label[:maxLabelLength]
+	}
+	return label
+}
+
+// label builds a sanitized integration label under the configured prefix
+func (c *Client) label(name string) string {
+	if c.opts.LabelPrefix == "" {
+		return SanitizeLabel(name)
+	}
+	return SanitizeLabel(c.opts.LabelPrefix + "-" + name)
+}
+
+// prNumberLabel identifies a PR by number (e.g. github-pr-42)
+func (c *Client) prNumberLabel(prNumber int) string {
+	return c.label(fmt.Sprintf("pr-%d", prNumber))
+}
+
+// prIssueClause is the JQL matching a PR's issues by label, including pre-prefix issues when
+// LegacyLabelLookup is set. Legacy labels carry no repository, so they can match a PR with the
+// same number in another repository; turn the lookup off once old issues are relabelled.
+func (c *Client) prIssueClause(repoName string, prNumber int) string {
+	clause := fmt.Sprintf(`labels = "%s" AND labels = "%s"`, c.prNumberLabel(prNumber), c.repoLabel(repoName))
+	if !c.opts.LegacyLabelLookup {
+		return clause
+	}
+	return fmt.Sprintf(`((%s) OR (labels = "github-pr" AND labels = "pr-%d"))`, clause, prNumber)
+}
+
+// detachedLabel marks an issue detached from a PR with /jira skip (e.g. github-detached-pr-42)
+func (c *Client) detachedLabel(prNumber int) string {
+	return c.label(fmt.Sprintf("detached-pr-%d", prNumber))
+}
+
+// waitingDependencyLabel marks an issue whose PR depends on a PR without an issue yet (e.g. github-depends-on-pr-12)
+func (c *Client) waitingDependencyLabel(prNumber int) string {
+	return c.label(fmt.Sprintf("depends-on-pr-%d", prNumber))
+}
+
+// repoLabel identifies the repository a PR belongs to (e.g. github-repo-api)
+func (c *Client) repoLabel(repoName string) string {
+	return c.label("repo-" + repoName)
+}
+
+// githubLabel maps a GitHub PR label to its Jira label (e.g. "needs review" -> github-label-needs-review),
+// namespaced so it can't collide with the integration's own pr/repo labels
+func (c *Client) githubLabel(name string) string {
+	return c.label("label-" + name)
+}
+
+// mergeLabels appends sanitized extra labels to labels, dropping duplicates and empty results
+func mergeLabels(labels, extra []string) []string {
+	seen := make(map[string]bool, len(labels)+len(extra))
+	for _, label := range labels {
+		seen[label] = true
+	}
+	for _, label := range extra {
+		label = SanitizeLabel(label)
+		if label != "" && !seen[label] {
+			seen[label] = true
+			labels = append(labels, label)
+		}
+	}
+	return labels
+}
+
+// prLabels returns all labels applied to a newly created PR issue
+func (c *Client) prLabels(repoName string, prNumber int) []string {
+	return []string{
+		c.label("pr"),
+		c.prNumberLabel(prNumber),
+		c.repoLabel(repoName),
+	}
+}
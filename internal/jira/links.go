@@ -0,0 +1,92 @@
+package jira
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/andygrunwald/go-jira"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// DefaultLinkType is the Jira issue link type used when none is configured
+const DefaultLinkType = "Relates"
+
+// issueKeyReference matches a Jira issue key such as PROJ-123
+var issueKeyReference = regexp.MustCompile(`\b([A-Z][A-Z0-9_]+-\d+)\b`)
+
+// notProjectKeys are common acronyms written like issue keys ("UTF-8", "SHA-256", "RFC-1234")
+var notProjectKeys = map[string]bool{
+	"AES": true, "ASCII": true, "CVE": true, "CWE": true, "ECMA": true, "GPT": true, "HTTP": true,
+	"IEC": true, "IEEE": true, "IPV": true, "ISO": true, "JSR": true, "MD": true, "PEP": true,
+	"RFC": true, "RSA": true, "SHA": true, "SSL": true, "TLS": true, "UCS": true, "UTF": true,
+}
+
+// ParseIssueKeys returns the distinct Jira issue keys mentioned in text, in order of first mention,
+// ignoring acronyms such as "UTF-8" that only look like keys
+func ParseIssueKeys(text string) []string {
+	var keys []string
+	seen := make(map[string]bool)
+	for _, match := range issueKeyReference.FindAllStringSubmatch(text, -1) {
+		key := match[1]
+		project, _, _ := strings.Cut(key, "-")
+		if notProjectKeys[project] {
+			continue
+		}
+		if !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// MarkWaitingDependency records on issueKey that its PR depends on PR dependency of the same
+// repository, which has no issue yet, so TakeWaitingDependents can link them once it does.
+// The mark lives in Jira, so it survives restarts.
+func (c *Client) MarkWaitingDependency(issueKey string, dependency int) error {
+	ctx, span := c.startSpan("MarkWaitingDependency", attribute.String("jira.issue", issueKey), attribute.Int("github.pr", dependency))
+	defer span.End()
+
+	if _, err := c.updateLabels(ctx, []jira.Issue{{Key: issueKey}}, []map[string]string{{"add": c.waitingDependencyLabel(dependency)}}); err != nil {
+		return recordError(span, fmt.Errorf("failed to mark %s as waiting on PR #%d: %w", issueKey, dependency, err))
+	}
+	return nil
+}
+
+// TakeWaitingDependents returns the issues marked as waiting on the PR and clears their mark
+func (c *Client) TakeWaitingDependents(repoName string, prNumber int) ([]string, error) {
+	ctx, span := c.startSpan("TakeWaitingDependents", attribute.String("github.repo", repoName), attribute.Int("github.pr", prNumber))
+	defer span.End()
+
+	jql := fmt.Sprintf(`%s AND labels = "%s" AND labels = "%s"`,
+		c.projectClause(repoName), c.waitingDependencyLabel(prNumber), c.repoLabel(repoName))
+	issues, _, err := c.client.Issue.SearchWithContext(ctx, jql, &jira.SearchOptions{MaxResults: 50, Fields: []string{"key"}})
+	if err != nil {
+		return nil, recordError(span, err)
+	}
+	keys, err := c.updateLabels(ctx, issues, []map[string]string{{"remove": c.waitingDependencyLabel(prNumber)}})
+	if err != nil {
+		return keys, recordError(span, fmt.Errorf("failed to clear the dependency mark for PR #%d: %w", prNumber, err))
+	}
+	return keys, nil
+}
+
+// LinkIssues creates an issue link of linkType from issueKey to otherKey
+func (c *Client) LinkIssues(issueKey, otherKey, linkType string) error {
+	ctx, span := c.startSpan("LinkIssues", attribute.String("jira.issue", issueKey), attribute.String("jira.linked_issue", otherKey))
+	defer span.End()
+
+	if linkType == "" {
+		linkType = DefaultLinkType
+	}
+	link := &jira.IssueLink{
+		Type:         jira.IssueLinkType{Name: linkType},
+		OutwardIssue: &jira.Issue{Key: issueKey},
+		InwardIssue:  &jira.Issue{Key: otherKey},
+	}
+	if _, err := c.client.Issue.AddLinkWithContext(ctx, link); err != nil {
+		return recordError(span, fmt.Errorf("failed to link %s to %s: %w", issueKey, otherKey, err))
+	}
+	return nil
+}
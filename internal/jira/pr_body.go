@@ -0,0 +1,61 @@
+package jira
+
+import (
+	"regexp"
+	"strings"
+)
+
+// maxPRBodyLength caps how much of the PR description is copied into the issue, in characters
+const maxPRBodyLength = 4000
+
+var (
+	htmlComment    = regexp.MustCompile(`(?s)<!--.*?-->`)
+	markdownHeader = regexp.MustCompile(`^(#{1,6})\s+(.*)$`)
+	excessBlank    = regexp.MustCompile(`\n{3,}`)
+)
+
+// formatPRBody converts a PR's Markdown description into Jira wiki markup for the issue
+// description: template comments are stripped, headings and code fences converted,
+// runs of blank lines collapsed and the result truncated to maxPRBodyLength
+func formatPRBody(body string) string {
+	body = htmlComment.ReplaceAllString(strings.ReplaceAll(body, "\r\n", "\n"), "")
+
+	lines := strings.Split(body, "\n")
+	inCode := false
+	for i, line := range lines {
+		line = strings.TrimRight(line, " \t")
+		switch {
+		case strings.HasPrefix(strings.TrimSpace(line), "```"):
+			lang := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), "```"))
+			if !inCode && lang != "" {
+				line = "{code:" + lang + "}"
+			} else {
+				line = "{code}"
+			}
+			inCode = !inCode
+		case !inCode:
+			if m := markdownHeader.FindStringSubmatch(line); m != nil {
+				line = "h" + string(rune('0'+len(m[1]))) + ". " + m[2]
+			}
+		}
+		lines[i] = line
+	}
+	if inCode {
+		lines = append(lines, "{code}")
+	}
+
+	body = strings.TrimSpace(excessBlank.ReplaceAllString(strings.Join(lines, "\n"), "\n\n"))
+	return truncateRunes(body, maxPRBodyLength)
+}
+
+// prBodySection renders the PR description block of the issue description, or "" when disabled or empty
+func (c *Client) prBodySection(prInfo PRIssueInfo) string {
+	if !c.opts.IncludePRBody {
+		return ""
+	}
+	body := formatPRBody(prInfo.PRBody)
+	if body == "" {
+		return ""
+	}
+	return "*Description:*\n" + body + "\n"
+}
@@ -0,0 +1,88 @@
+package jira
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// projectKeyPattern is Jira's default project key format: a letter, then 1-9 letters, digits or underscores
+var projectKeyPattern = regexp.MustCompile(`^[A-Z][A-Z0-9_]{1,9}$`)
+
+// maxProjectKeyLength is the longest key projectKeyPattern accepts
+const maxProjectKeyLength = 10
+
+// nonAlphanumeric splits repo names into words
+var nonAlphanumeric = regexp.MustCompile(`[^a-z0-9]+`)
+
+// ProjectKeyRules derive a Jira project key from a repository name
+type ProjectKeyRules struct {
+	// Overrides maps repo names straight to project keys, bypassing the other rules
+	Overrides map[string]string
+	// StripPrefixes are removed from the start of repo names (first match wins), e.g. "team-"
+	StripPrefixes []string
+	// Acronym uses the first letter of each word: service-api -> SA
+	Acronym bool
+}
+
+// ValidProjectKey reports whether key matches Jira's default project key format
+func ValidProjectKey(key string) bool {
+	return projectKeyPattern.MatchString(key)
+}
+
+// Derive returns the project key for repoName: team-service-api -> SERVICEAPI with the
+// prefix "team-" stripped, or SA as an acronym
+func (r ProjectKeyRules) Derive(repoName string) (string, error) {
+	if key, ok := r.Overrides[repoName]; ok {
+		key = strings.ToUpper(key)
+		if !ValidProjectKey(key) {
+			return "", fmt.Errorf("project key override %q for %s is not a valid Jira project key", key, repoName)
+		}
+		return key, nil
+	}
+
+	name := strings.ToLower(repoName)
+	for _, prefix := range r.StripPrefixes {
+		if stripped, ok := strings.CutPrefix(name, strings.ToLower(prefix)); ok && stripped != "" {
+			name = stripped
+			break
+		}
+	}
+
+	words := strings.Fields(nonAlphanumeric.ReplaceAllString(name, " "))
+	var key string
+	if r.Acronym && len(words) > 1 {
+		for _, word := range words {
+			key += word[:1]
+		}
+	} else {
+		key = strings.Join(words, "")
+	}
+
+	key = strings.ToUpper(key)
+	if len(key) > maxProjectKeyLength {
+		key = key[:maxProjectKeyLength]
+	}
+	if !ValidProjectKey(key) {
+		return "", fmt.Errorf("cannot derive a valid Jira project key from repository %s (got %q)", repoName, key)
+	}
+	return key, nil
+}
+
+// getProjectKey derives the repository's own project key when ProjectKeyRules are configured
+func (c *Client) getProjectKey(repoName string) (string, bool) {
+	if c.opts.ProjectKeyRules == nil {
+		return "", false
+	}
+	key, err := c.opts.ProjectKeyRules.Derive(repoName)
+	return key, err == nil
+}
+
+// ProjectFor returns the project a repository's PR issues are created in by default: the key
+// derived from the repo name when ProjectKeyRules are set and yield a valid key, else ProjectKey
+func (c *Client) ProjectFor(repoName string) string {
+	if key, ok := c.getProjectKey(repoName); ok {
+		return key
+	}
+	return c.opts.ProjectKey
+}
@@ -0,0 +1,62 @@
+package jira
+
+import (
+	"fmt"
+
+	"github.com/andygrunwald/go-jira"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// githubIcon is shown next to PR links in Jira's "Web links" panel
+const githubIcon = "https://github.com/favicon.ico"
+
+// prRemoteLinkID is the stable global ID of a PR's remote link; Jira updates rather than
+// duplicates a link when one with the same global ID already exists
+func prRemoteLinkID(repoName string, prNumber int) string {
+	return fmt.Sprintf("github-pr:%s#%d", repoName, prNumber)
+}
+
+// UpsertPRRemoteLink adds (or refreshes) a web link from the issue to its GitHub PR
+func (c *Client) UpsertPRRemoteLink(issueKey string, prInfo PRIssueInfo) error {
+	ctx, span := c.startSpan("UpsertPRRemoteLink", attribute.String("jira.issue", issueKey),
+		attribute.String("github.repo", prInfo.RepoName), attribute.Int("github.pr", prInfo.PRNumber))
+	defer span.End()
+
+	link := &jira.RemoteLink{
+		GlobalID:     prRemoteLinkID(prInfo.RepoName, prInfo.PRNumber),
+		Application:  &jira.RemoteLinkApplication{Type: "com.github", Name: "GitHub"},
+		Relationship: "pull request",
+		Object: &jira.RemoteLinkObject{
+			URL:     prInfo.PRLink,
+			Title:   fmt.Sprintf("%s #%d: %s", prInfo.RepoName, prInfo.PRNumber, prInfo.PRTitle),
+			Summary: fmt.Sprintf("%s → %s by %s", prInfo.SourceBranch, prInfo.TargetBranch, prInfo.Author),
+			Icon:    &jira.RemoteLinkIcon{Url16x16: githubIcon, Title: "GitHub pull request"},
+			Status:  &jira.RemoteLinkStatus{Resolved: prInfo.Action == "merged" || prInfo.Action == "closed"},
+		},
+	}
+	if _, _, err := c.client.Issue.AddRemoteLinkWithContext(ctx, issueKey, link); err != nil {
+		return recordError(span, fmt.Errorf("failed to link %s to PR #%d: %w", issueKey, prInfo.PRNumber, err))
+	}
+	return nil
+}
+
+// SyncPRRemoteLinks refreshes the PR link on every issue tracking the PR (e.g. after a title
+// edit, or to mark it resolved on merge), returning the updated issue keys
+func (c *Client) SyncPRRemoteLinks(prInfo PRIssueInfo) ([]string, error) {
+	ctx, span := c.startSpan("SyncPRRemoteLinks", attribute.String("github.repo", prInfo.RepoName), attribute.Int("github.pr", prInfo.PRNumber))
+	defer span.End()
+
+	scoped := c.WithContext(ctx)
+	issues, err := scoped.searchPRIssues(prInfo.RepoName, prInfo.PRNumber, 50)
+	if err != nil {
+		return nil, recordError(span, err)
+	}
+	var keys []string
+	for _, issue := range issues {
+		if err := scoped.UpsertPRRemoteLink(issue.Key, prInfo); err != nil {
+			return keys, recordError(span, err)
+		}
+		keys = append(keys, issue.Key)
+	}
+	return keys, nil
+}
@@ -0,0 +1,50 @@
+package jira
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/andygrunwald/go-jira"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// CreateSelfTestIssue creates a throwaway issue used to check permissions and workflow at startup
+func (c *Client) CreateSelfTestIssue() (*jira.Issue, error) {
+	ctx, span := c.startSpan("CreateSelfTestIssue")
+	defer span.End()
+
+	issueData := jira.Issue{
+		Fields: &jira.IssueFields{
+			Project:     jira.Project{Key: c.opts.ProjectKey},
+			Type:        jira.IssueType{Name: "Task"},
+			Summary:     "[self-test] GitHub integration startup check",
+			Description: fmt.Sprintf("Created by the GitHub integration self-test at %s. Safe to delete.", time.Now().Format(time.RFC3339)),
+			Labels:      []string{c.label("selftest")},
+			Unknowns:    fieldDefaults(c.opts.FieldDefaults),
+		},
+	}
+
+	issue, resp, err := c.client.Issue.CreateWithContext(ctx, &issueData)
+	if err != nil {
+		err = describeCreateError(resp, err, c.opts.ProjectKey)
+		return nil, recordError(span, fmt.Errorf("failed to create self-test issue in project %s: %w", c.opts.ProjectKey, err))
+	}
+	span.SetAttributes(attribute.String("jira.issue", issue.Key))
+	return issue, nil
+}
+
+// MoveIssueToStatus transitions an issue by key to targetStatus
+func (c *Client) MoveIssueToStatus(issueKey, targetStatus string) error {
+	return c.moveToStatus(issueKey, targetStatus)
+}
+
+// DeleteIssue permanently deletes an issue
+func (c *Client) DeleteIssue(issueKey string) error {
+	ctx, span := c.startSpan("DeleteIssue", attribute.String("jira.issue", issueKey))
+	defer span.End()
+
+	if _, err := c.client.Issue.DeleteWithContext(ctx, issueKey); err != nil {
+		return recordError(span, fmt.Errorf("failed to delete %s: %w", issueKey, err))
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package jira
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/andygrunwald/go-jira"
+	"github.com/trivago/tgo/tcontainer"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// activeSprintTTL is how long a board's active sprint (or lack of one) is remembered
+const activeSprintTTL = 5 * time.Minute
+
+// errAgileUnavailable is returned when the Jira site has no Agile API (e.g. no Jira Software)
+var errAgileUnavailable = errors.New("jira agile API is not available")
+
+// sprintCache remembers the board's active sprint; it is shared by client copies
+type sprintCache struct {
+	mu        sync.Mutex
+	sprintID  int
+	found     bool
+	fetchedAt time.Time
+}
+
+// ActiveSprint returns the ID of the configured board's active sprint (cached briefly).
+// found is false when the board has no active sprint; with several, the first is used.
+// A missing Agile API is cached like no active sprint; other failures are retried on the next call.
+func (c *Client) ActiveSprint() (sprintID int, found bool, err error) {
+	c.sprints.mu.Lock()
+	defer c.sprints.mu.Unlock()
+	if !c.sprints.fetchedAt.IsZero() && time.Since(c.sprints.fetchedAt) < activeSprintTTL {
+		return c.sprints.sprintID, c.sprints.found, nil
+	}
+
+	ctx, span := c.startSpan("ActiveSprint", attribute.Int("jira.board", c.opts.SprintBoardID))
+	defer span.End()
+
+	list, resp, err := c.client.Board.GetAllSprintsWithOptionsWithContext(ctx, c.opts.SprintBoardID, &jira.GetAllSprintsOptions{State: "active"})
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			err = errAgileUnavailable
+			// Remember it, so an API the site doesn't have isn't asked on every PR
+			c.sprints.sprintID, c.sprints.found, c.sprints.fetchedAt = 0, false, time.Now()
+		}
+		return 0, false, recordError(span, fmt.Errorf("failed to get active sprint of board %d: %w", c.opts.SprintBoardID, err))
+	}
+
+	c.sprints.sprintID, c.sprints.found = 0, false
+	if len(list.Values) > 0 {
+		c.sprints.sprintID, c.sprints.found = list.Values[0].ID, true
+	}
+	c.sprints.fetchedAt = time.Now()
+	return c.sprints.sprintID, c.sprints.found, nil
+}
+
+// applySprint puts a new issue in the board's active sprint when sprint assignment is configured.
+// Without an active sprint, or when it can't be looked up, the issue is created outside any sprint.
+func (c *Client) applySprint(fields *jira.IssueFields) {
+	if c.opts.SprintField == "" || c.opts.SprintBoardID == 0 {
+		return
+	}
+	sprintID, found, err := c.ActiveSprint()
+	if err != nil {
+		if c.opts.Logger != nil {
+			c.opts.Logger.Error(fmt.Sprintf("Creating issue outside any sprint: %v", err))
+		}
+		return
+	}
+	if !found {
+		return
+	}
+
+	if fields.Unknowns == nil {
+		fields.Unknowns = tcontainer.NewMarshalMap()
+	}
+	fields.Unknowns[c.opts.SprintField] = sprintID
+}
@@ -0,0 +1,42 @@
+package jira
+
+import (
+	"errors"
+	"fmt"
+)
+
+// StateMachine lists, for each status, the statuses an issue may move to next. Statuses
+// without an entry are unrestricted, so an empty machine allows every move.
+type StateMachine map[string][]string
+
+// Allows reports whether an issue in status from may be moved to status to
+func (m StateMachine) Allows(from, to string) bool {
+	next, ok := m[from]
+	if !ok || from == to {
+		return true
+	}
+	for _, status := range next {
+		if status == to {
+			return true
+		}
+	}
+	return false
+}
+
+// TransitionNotAllowedError is returned when the state machine rejects a move, typically
+// because webhook deliveries arrived out of order (e.g. reopening a released issue)
+type TransitionNotAllowedError struct {
+	IssueKey string
+	From     string
+	To       string
+}
+
+func (e *TransitionNotAllowedError) Error() string {
+	return fmt.Sprintf("moving %s from %s to %s is not allowed by JIRA_STATE_MACHINE", e.IssueKey, e.From, e.To)
+}
+
+// IsTransitionNotAllowed reports whether err (or any error joined into it) is a rejected move
+func IsTransitionNotAllowed(err error) bool {
+	var notAllowed *TransitionNotAllowedError
+	return errors.As(err, &notAllowed)
+}
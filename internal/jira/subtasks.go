@@ -0,0 +1,73 @@
+package jira
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/andygrunwald/go-jira"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// DefaultSubTaskType is the issue type of per-component sub-tasks
+const DefaultSubTaskType = "Sub-task"
+
+// subTaskLabel identifies a PR's sub-tasks (e.g. github-pr-42-subtask); it differs from the PR
+// number label so PR lookups and transitions only ever see the parent issue
+func (c *Client) subTaskLabel(prNumber int) string {
+	return c.label(fmt.Sprintf("pr-%d-subtask", prNumber))
+}
+
+// CreatePRSubTask creates a sub-task of parentKey covering one component's files of the PR
+func (c *Client) CreatePRSubTask(parentKey, issueType string, prInfo PRIssueInfo, component string, files []string) (*jira.Issue, error) {
+	ctx, span := c.startSpan("CreatePRSubTask", attribute.String("jira.issue", parentKey), attribute.String("github.repo", prInfo.RepoName), attribute.Int("github.pr", prInfo.PRNumber))
+	defer span.End()
+
+	if issueType == "" {
+		issueType = DefaultSubTaskType
+	}
+	issueData := jira.Issue{
+		Fields: &jira.IssueFields{
+			Project:     jira.Project{Key: c.opts.ProjectKey},
+			Type:        jira.IssueType{Name: issueType},
+			Parent:      &jira.Parent{Key: parentKey},
+			Summary:     buildSummary(prInfo.PRNumber, fmt.Sprintf("[%s] %s", component, prInfo.PRTitle)),
+			Description: fmt.Sprintf("*%s* changes of [PR #%d|%s]:\n• %s", component, prInfo.PRNumber, prInfo.PRLink, strings.Join(files, "\n• ")),
+			Labels:      []string{c.subTaskLabel(prInfo.PRNumber), c.repoLabel(prInfo.RepoName)},
+			Unknowns:    fieldDefaults(c.opts.FieldDefaults),
+		},
+	}
+
+	issue, resp, err := c.client.Issue.CreateWithContext(ctx, &issueData)
+	if err != nil {
+		err = describeCreateError(resp, err, c.opts.ProjectKey)
+		return nil, recordError(span, fmt.Errorf("failed to create %s sub-task of %s: %w", component, parentKey, err))
+	}
+	span.SetAttributes(attribute.String("jira.subtask", issue.Key))
+	return issue, nil
+}
+
+// ClosePRSubTasks moves the PR's sub-tasks that aren't there yet to status, returning the keys moved
+func (c *Client) ClosePRSubTasks(repoName string, prNumber int, status string) ([]string, error) {
+	ctx, span := c.startSpan("ClosePRSubTasks", attribute.String("github.repo", repoName), attribute.Int("github.pr", prNumber))
+	defer span.End()
+
+	jql := fmt.Sprintf(`%s AND labels = "%s" AND labels = "%s"`,
+		c.projectClause(repoName), c.subTaskLabel(prNumber), c.repoLabel(repoName))
+	issues, _, err := c.client.Issue.SearchWithContext(ctx, jql, &jira.SearchOptions{MaxResults: 100})
+	if err != nil {
+		return nil, recordError(span, fmt.Errorf("failed to find sub-tasks of PR #%d: %w", prNumber, err))
+	}
+
+	scoped := c.WithContext(ctx)
+	var keys []string
+	for _, issue := range issues {
+		if issueStatus(issue) == status {
+			continue
+		}
+		if err := scoped.moveToStatus(issue.Key, status); err != nil {
+			return keys, recordError(span, fmt.Errorf("failed to move sub-task %s to %s: %w", issue.Key, status, err))
+		}
+		keys = append(keys, issue.Key)
+	}
+	return keys, nil
+}
@@ -0,0 +1,122 @@
+package jira
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"text/template"
+	"unicode/utf8"
+)
+
+// maxSummaryLength is Jira's limit on an issue summary, in characters
+const maxSummaryLength = 255
+
+// buildSummary renders "PR #N: title", trimming the title with an ellipsis to fit Jira's limit
+func buildSummary(prNumber int, title string) string {
+	return summaryWithPrefix(fmt.Sprintf("PR #%d: ", prNumber), title)
+}
+
+// summaryData is what a summary template renders: the PR's fields plus the monorepo Area its files share
+type summaryData struct {
+	PRIssueInfo
+	Area string
+}
+
+// ParseSummaryTemplate parses a JIRA_SUMMARY_TEMPLATE such as "[{{.RepoName}}] {{.PRTitle}} (#{{.PRNumber}})"
+// and renders it once against a sample PR, so unknown fields fail at startup rather than per issue
+func ParseSummaryTemplate(text string) (*template.Template, error) {
+	tmpl, err := template.New("summary").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid summary template: %w", err)
+	}
+	sample := summaryData{PRIssueInfo: PRIssueInfo{PRNumber: 1, PRTitle: "Sample", RepoName: "repo", Author: "octocat",
+		SourceBranch: "feature", TargetBranch: "main", FilesChanged: []string{"dir/file.go"}}, Area: "dir"}
+	if err := tmpl.Execute(new(strings.Builder), sample); err != nil {
+		return nil, fmt.Errorf("invalid summary template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// prSummary is the summary of a PR issue: the configured template, or else "PR #N: title" led by
+// the area of the monorepo the PR touches ("[payments] PR #N: title") when SummaryPathDepth is set
+func (c *Client) prSummary(prInfo PRIssueInfo) string {
+	if c.opts.SummaryTemplate != nil {
+		if summary, ok := c.renderSummary(prInfo); ok {
+			return summary
+		}
+	}
+
+	prefix := fmt.Sprintf("PR #%d: ", prInfo.PRNumber)
+	if area := pathArea(prInfo.FilesChanged, c.opts.SummaryPathDepth); area != "" {
+		prefix = fmt.Sprintf("[%s] %s", area, prefix)
+	}
+	return summaryWithPrefix(prefix, prInfo.PRTitle)
+}
+
+// renderSummary executes the summary template as a single line capped at Jira's limit; it reports
+// false when rendering fails or yields nothing, so the default summary is used instead
+func (c *Client) renderSummary(prInfo PRIssueInfo) (string, bool) {
+	depth := c.opts.SummaryPathDepth
+	if depth <= 0 {
+		depth = 1
+	}
+	var out strings.Builder
+	if err := c.opts.SummaryTemplate.Execute(&out, summaryData{PRIssueInfo: prInfo, Area: pathArea(prInfo.FilesChanged, depth)}); err != nil {
+		return "", false
+	}
+	summary := strings.Join(strings.Fields(out.String()), " ")
+	if summary == "" {
+		return "", false
+	}
+	return truncateRunes(summary, maxSummaryLength), true
+}
+
+// summaryWithPrefix joins prefix and title, trimming the title to fit Jira's limit
+func summaryWithPrefix(prefix, title string) string {
+	return prefix + truncateRunes(title, maxSummaryLength-utf8.RuneCountInString(prefix))
+}
+
+// pathArea returns the directory path (at most depth levels) shared by every file, or "" when
+// depth is 0, there are no files, or they have no directory in common
+func pathArea(files []string, depth int) string {
+	if depth <= 0 || len(files) == 0 {
+		return ""
+	}
+
+	var common []string
+	for i, file := range files {
+		dir := path.Dir(file)
+		if dir == "." || dir == "/" {
+			return ""
+		}
+		parts := strings.Split(strings.TrimPrefix(dir, "/"), "/")
+		if i == 0 {
+			common = parts
+			continue
+		}
+		n := 0
+		for n < len(common) && n < len(parts) && common[n] == parts[n] {
+			n++
+		}
+		if common = common[:n]; n == 0 {
+			return ""
+		}
+	}
+	if len(common) > depth {
+		common = common[:depth]
+	}
+	return strings.Join(common, "/")
+}
+
+// truncateRunes shortens s to at most limit characters, ending in "…" when cut
+func truncateRunes(s string, limit int) string {
+	if utf8.RuneCountInString(s) <= limit {
+		return s
+	}
+	if limit <= 1 {
+		return "…"
+	}
+
+	runes := []rune(s)
+	return string(runes[:limit-1]) + "…"
+}
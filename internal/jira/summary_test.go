@@ -0,0 +1,51 @@
+package jira
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestBuildSummary(t *testing.T) {
+	tests := []struct {
+		name      string
+		title     string
+		want      string
+		truncated bool
+	}{
+		{name: "short title", title: "Fix login", want: "PR #42: Fix login"},
+		{name: "exactly at the cap", title: strings.Repeat("a", maxSummaryLength-len("PR #42: "))},
+		{name: "long ascii title", title: strings.Repeat("a", 400), truncated: true},
+		{name: "long multi-byte title", title: strings.Repeat("日本語", 200), truncated: true},
+		{name: "long emoji title", title: strings.Repeat("🚀", 300), truncated: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := buildSummary(42, tt.title)
+
+			if tt.want != "" && got != tt.want {
+				t.Errorf("buildSummary = %q, want %q", got, tt.want)
+			}
+			if !strings.HasPrefix(got, "PR #42: ") {
+				t.Errorf("summary %q lost its PR prefix", got)
+			}
+			if !utf8.ValidString(got) {
+				t.Errorf("summary %q splits a multi-byte character", got)
+			}
+			if n := utf8.RuneCountInString(got); n > maxSummaryLength {
+				t.Errorf("summary is %d characters, over the %d cap", n, maxSummaryLength)
+			}
+			if tt.truncated {
+				if !strings.HasSuffix(got, "…") {
+					t.Errorf("truncated summary %q does not end in an ellipsis", got)
+				}
+				if n := utf8.RuneCountInString(got); n != maxSummaryLength {
+					t.Errorf("truncated summary is %d characters, want %d", n, maxSummaryLength)
+				}
+			} else if strings.HasSuffix(got, "…") {
+				t.Errorf("summary %q was truncated but fits", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package jira
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github_integration/internal/metrics"
+	"github_integration/internal/retry"
+)
+
+// defaultRateLimitWait is used when a 429 carries no usable Retry-After header
+const defaultRateLimitWait = 10 * time.Second
+
+var (
+	requestsInFlight = metrics.NewGauge("jira_requests_in_flight", "Jira API requests currently in flight")
+	rateLimited      = metrics.NewCounter("jira_rate_limited_total", "Jira API responses rejected with 429 Too Many Requests")
+)
+
+// rateLimitedError is a 429 response that may be retried after Wait; it keeps the
+// response so the final attempt can be handed back to the caller unchanged
+type rateLimitedError struct {
+	Wait time.Duration
+	resp *http.Response
+}
+
+func (e *rateLimitedError) Error() string             { return "jira: rate limited (429)" }
+func (e *rateLimitedError) Retryable() bool           { return true }
+func (e *rateLimitedError) RetryAfter() time.Duration { return e.Wait }
+
+// throttledTransport bounds concurrent Jira requests and retries 429s after the advised delay
+type throttledTransport struct {
+	base  http.RoundTripper
+	slots chan struct{} // nil means unlimited
+	retry retry.Policy
+}
+
+func newThrottledTransport(base http.RoundTripper, maxConcurrency int, policy retry.Policy) *throttledTransport {
+	t := &throttledTransport{base: base, retry: policy}
+	if maxConcurrency > 0 {
+		t.slots = make(chan struct{}, maxConcurrency)
+	}
+	return t
+}
+
+func (t *throttledTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var resp, limitedResp *http.Response
+	attempt := 0
+	err := t.retry.Do(req.Context(), func() error {
+		attempt++
+		outgoing := req
+		if attempt > 1 {
+			// The previous 429 is being retried, so its response is no longer needed
+			if limitedResp != nil {
+				io.Copy(io.Discard, limitedResp.Body)
+				limitedResp.Body.Close()
+				limitedResp = nil
+			}
+			// Only requests whose body can be replayed are retried
+			if req.Body != nil && req.GetBody == nil {
+				return errors.New("jira: request body cannot be replayed")
+			}
+			outgoing = req.Clone(req.Context())
+			if req.GetBody != nil {
+				body, err := req.GetBody()
+				if err != nil {
+					return err
+				}
+				outgoing.Body = body
+			}
+		}
+
+		r, err := t.send(outgoing)
+		if err != nil {
+			return err
+		}
+		if r.StatusCode == http.StatusTooManyRequests {
+			rateLimited.Inc()
+			limitedResp = r
+			return &rateLimitedError{Wait: parseRetryAfter(r.Header.Get("Retry-After")), resp: r}
+		}
+		resp = r
+		return nil
+	})
+
+	// Out of attempts (or the advised wait was too long): return the 429 itself
+	var limited *rateLimitedError
+	if errors.As(err, &limited) {
+		return limited.resp, nil
+	}
+	return resp, err
+}
+
+// send performs one request while holding a concurrency slot
+func (t *throttledTransport) send(req *http.Request) (*http.Response, error) {
+	if t.slots != nil {
+		select {
+		case t.slots <- struct{}{}:
+			defer func() { <-t.slots }()
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+	}
+
+	requestsInFlight.Inc()
+	defer requestsInFlight.Dec()
+
+	return t.base.RoundTrip(req)
+}
+
+// parseRetryAfter reads a Retry-After header given in seconds or as an HTTP date
+func parseRetryAfter(value string) time.Duration {
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		if wait := time.Until(at); wait > 0 {
+			return wait
+		}
+		return 0
+	}
+	return defaultRateLimitWait
+}
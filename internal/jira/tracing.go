@@ -0,0 +1,34 @@
+package jira
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("github_integration/internal/jira")
+
+// WithContext returns a copy of the client whose API calls use ctx (and any span it carries).
+// The copy is scoped to one unit of work: use it only for as long as ctx is meant to live and
+// drop it afterwards, rather than storing it past the request ctx belongs to.
+func (c *Client) WithContext(ctx context.Context) *Client {
+	clone := *c
+	clone.ctx = ctx
+	return &clone
+}
+
+// startSpan starts a child span for a Jira API call under the client's context
+func (c *Client) startSpan(name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	attrs = append(attrs, attribute.String("jira.project", c.opts.ProjectKey))
+	return tracer.Start(c.ctx, "jira."+name, trace.WithAttributes(attrs...))
+}
+
+// recordError marks the span as failed and passes err through
+func recordError(span trace.Span, err error) error {
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+	return err
+}
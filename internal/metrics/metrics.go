@@ -0,0 +1,123 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// Counter is a monotonically increasing value
+type Counter struct {
+	value atomic.Int64
+}
+
+// Inc adds one to the counter
+func (c *Counter) Inc() { c.value.Add(1) }
+
+// Add adds n to the counter
+func (c *Counter) Add(n int64) { c.value.Add(n) }
+
+// Value returns the current count
+func (c *Counter) Value() int64 { return c.value.Load() }
+
+// Gauge is a value that can go up and down
+type Gauge struct {
+	value atomic.Int64
+}
+
+// Inc adds one to the gauge
+func (g *Gauge) Inc() { g.value.Add(1) }
+
+// Dec subtracts one from the gauge
+func (g *Gauge) Dec() { g.value.Add(-1) }
+
+// Set replaces the gauge value
+func (g *Gauge) Set(n int64) { g.value.Store(n) }
+
+// Value returns the current gauge value
+func (g *Gauge) Value() int64 { return g.value.Load() }
+
+// metric is a registered counter or gauge with its exposition metadata
+type metric struct {
+	name  string
+	help  string
+	kind  string
+	value func() int64
+}
+
+// Registry holds named metrics and renders them in Prometheus text format
+type Registry struct {
+	mu      sync.RWMutex
+	metrics map[string]metric
+}
+
+// Default is the process-wide registry used by all packages
+var Default = NewRegistry()
+
+// NewRegistry creates an empty registry
+func NewRegistry() *Registry {
+	return &Registry{metrics: make(map[string]metric)}
+}
+
+// NewCounter registers a counter in the default registry
+func NewCounter(name, help string) *Counter {
+	return Default.NewCounter(name, help)
+}
+
+// NewGauge registers a gauge in the default registry
+func NewGauge(name, help string) *Gauge {
+	return Default.NewGauge(name, help)
+}
+
+// NewCounter registers and returns a counter
+func (r *Registry) NewCounter(name, help string) *Counter {
+	c := &Counter{}
+	r.register(metric{name: name, help: help, kind: "counter", value: c.Value})
+	return c
+}
+
+// NewGauge registers and returns a gauge
+func (r *Registry) NewGauge(name, help string) *Gauge {
+	g := &Gauge{}
+	r.register(metric{name: name, help: help, kind: "gauge", value: g.Value})
+	return g
+}
+
+// register adds a metric, panicking on duplicates since that is a programming error
+func (r *Registry) register(m metric) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.metrics[m.name]; exists {
+		panic(fmt.Sprintf("metrics: duplicate metric %q", m.name))
+	}
+	r.metrics[m.name] = m
+}
+
+// WritePrometheus writes all metrics sorted by name in Prometheus text exposition format
+func (r *Registry) WritePrometheus(w io.Writer) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, 0, len(r.metrics))
+	for name := range r.metrics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		m := r.metrics[name]
+		fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n%s %d\n", m.name, m.help, m.name, m.kind, m.name, m.value())
+	}
+}
+
+// Handler serves the registry for Prometheus scraping
+func (r *Registry) Handler() http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		r.WritePrometheus(w)
+	}
+}
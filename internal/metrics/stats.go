@@ -0,0 +1,50 @@
+package metrics
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// CounterSet is a family of counters keyed by label (e.g. event type), created on first use
+type CounterSet struct {
+	mu       sync.RWMutex
+	counters map[string]*atomic.Int64
+}
+
+// NewCounterSet creates an empty counter family
+func NewCounterSet() *CounterSet {
+	return &CounterSet{counters: make(map[string]*atomic.Int64)}
+}
+
+// Inc adds one to the counter for key
+func (s *CounterSet) Inc(key string) {
+	s.mu.RLock()
+	counter, ok := s.counters[key]
+	s.mu.RUnlock()
+
+	if !ok {
+		s.mu.Lock()
+		if counter, ok = s.counters[key]; !ok {
+			counter = &atomic.Int64{}
+			s.counters[key] = counter
+		}
+		s.mu.Unlock()
+	}
+	counter.Add(1)
+}
+
+// Snapshot returns every counter's value, zeroing them atomically when reset is set
+func (s *CounterSet) Snapshot(reset bool) map[string]int64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	values := make(map[string]int64, len(s.counters))
+	for key, counter := range s.counters {
+		if reset {
+			values[key] = counter.Swap(0)
+		} else {
+			values[key] = counter.Load()
+		}
+	}
+	return values
+}
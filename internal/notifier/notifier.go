@@ -0,0 +1,75 @@
+package notifier
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github_integration/internal/utils"
+)
+
+// Notifier delivers operational alerts to humans
+type Notifier interface {
+	Notify(title, message string) error
+}
+
+// LogNotifier writes alerts to the service log (used when no alert channel is configured)
+type LogNotifier struct {
+	logger *utils.Logger
+}
+
+// NewLogNotifier creates a notifier that only logs alerts
+func NewLogNotifier(logger *utils.Logger) *LogNotifier {
+	return &LogNotifier{logger: logger}
+}
+
+// Notify logs the alert at error level
+func (n *LogNotifier) Notify(title, message string) error {
+	n.logger.Error(fmt.Sprintf("ALERT: %s - %s", title, message))
+	return nil
+}
+
+// SlackNotifier posts alerts to a Slack incoming webhook
+type SlackNotifier struct {
+	webhookURL string
+	httpClient *http.Client
+}
+
+// NewSlackNotifier creates a notifier for the given Slack incoming webhook URL
+func NewSlackNotifier(webhookURL string) *SlackNotifier {
+	return &SlackNotifier{
+		webhookURL: webhookURL,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Notify posts the alert as a Slack message
+func (n *SlackNotifier) Notify(title, message string) error {
+	body, err := json.Marshal(map[string]string{
+		"text": fmt.Sprintf("*%s*\n%s", title, message),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode Slack message: %w", err)
+	}
+
+	resp, err := n.httpClient.Post(n.webhookURL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to send Slack alert: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("slack webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// Func adapts a function to the Notifier interface
+type Func func(title, message string) error
+
+// Notify calls f
+func (f Func) Notify(title, message string) error {
+	return f(title, message)
+}
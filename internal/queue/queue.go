@@ -0,0 +1,210 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrQueueFull is returned when the queue has no room for another job
+var ErrQueueFull = errors.New("event queue is full")
+
+// ErrQueueClosed is returned when jobs are enqueued after shutdown began
+var ErrQueueClosed = errors.New("event queue is closed")
+
+// Job is a unit of asynchronous webhook processing
+type Job struct {
+	ID string
+	// Key orders jobs: jobs sharing a key run one at a time in enqueue order, while jobs with
+	// different (or no) keys run concurrently
+	Key string
+	Run func()
+	// Abandon, when set, is called instead of Run for jobs dropped by Shutdown's deadline
+	Abandon func()
+}
+
+// Queue is a bounded job queue drained by a fixed pool of workers
+type Queue struct {
+	jobs      chan Job
+	workers   int
+	wg        sync.WaitGroup
+	stop      chan struct{}
+	stopOnce  sync.Once
+	processed atomic.Int64
+
+	mu     sync.RWMutex
+	closed bool
+
+	// keyMu guards the per-key ordering state: a key present in active has a job queued or
+	// running, and the slice holds the jobs waiting behind it
+	keyMu   sync.Mutex
+	active  map[string][]Job
+	waiting int
+}
+
+// New creates a queue holding up to size pending jobs processed by workers goroutines
+func New(workers, size int) *Queue {
+	return &Queue{
+		jobs:    make(chan Job, size),
+		workers: workers,
+		stop:    make(chan struct{}),
+		active:  make(map[string][]Job),
+	}
+}
+
+// Start launches the worker goroutines
+func (q *Queue) Start() {
+	for i := 0; i < q.workers; i++ {
+		q.wg.Add(1)
+		go func() {
+			defer q.wg.Done()
+			for {
+				// Check stop first so a worker never picks up another job once the deadline passed
+				select {
+				case <-q.stop:
+					return
+				default:
+				}
+				select {
+				case job, ok := <-q.jobs:
+					if !ok {
+						return
+					}
+					q.runKeyed(job)
+				case <-q.stop:
+					return
+				}
+			}
+		}()
+	}
+}
+
+// runKeyed runs job, then any jobs that queued up behind its key in the meantime
+func (q *Queue) runKeyed(job Job) {
+	for {
+		job.Run()
+		q.processed.Add(1)
+
+		if job.Key == "" {
+			return
+		}
+		select {
+		case <-q.stop:
+			// Leave waiting jobs for Shutdown to abandon
+			return
+		default:
+		}
+
+		next, ok := q.nextForKey(job.Key)
+		if !ok {
+			return
+		}
+		job = next
+	}
+}
+
+// nextForKey pops the next job waiting behind key, releasing the key when there is none
+func (q *Queue) nextForKey(key string) (Job, bool) {
+	q.keyMu.Lock()
+	defer q.keyMu.Unlock()
+
+	waiting := q.active[key]
+	if len(waiting) == 0 {
+		delete(q.active, key)
+		return Job{}, false
+	}
+	q.active[key] = waiting[1:]
+	q.waiting--
+	return waiting[0], true
+}
+
+// Enqueue adds a job without blocking
+func (q *Queue) Enqueue(job Job) error {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	if q.closed {
+		return ErrQueueClosed
+	}
+	if job.Key == "" {
+		select {
+		case q.jobs <- job:
+			return nil
+		default:
+			return ErrQueueFull
+		}
+	}
+
+	q.keyMu.Lock()
+	defer q.keyMu.Unlock()
+
+	// Jobs waiting behind a key count against the queue's capacity too
+	if len(q.jobs)+q.waiting >= cap(q.jobs) {
+		return ErrQueueFull
+	}
+	if waiting, busy := q.active[job.Key]; busy {
+		q.active[job.Key] = append(waiting, job)
+		q.waiting++
+		return nil
+	}
+	select {
+	case q.jobs <- job:
+		q.active[job.Key] = nil
+		return nil
+	default:
+		return ErrQueueFull
+	}
+}
+
+// Close stops accepting jobs and waits for workers to finish the ones already queued
+func (q *Queue) Close() {
+	q.Shutdown(context.Background())
+}
+
+// Shutdown stops accepting jobs and drains the queue until ctx is done. Jobs still queued at
+// the deadline are abandoned (their Abandon hook runs) once in-flight jobs have finished.
+// It returns how many jobs finished while draining and how many were abandoned.
+func (q *Queue) Shutdown(ctx context.Context) (processed, abandoned int) {
+	before := q.processed.Load()
+	q.mu.Lock()
+	if !q.closed {
+		q.closed = true
+		close(q.jobs)
+	}
+	q.mu.Unlock()
+
+	drained := make(chan struct{})
+	go func() {
+		q.wg.Wait()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+		return int(q.processed.Load() - before), 0
+	case <-ctx.Done():
+	}
+
+	q.stopOnce.Do(func() { close(q.stop) })
+	var dropped []Job
+	for job := range q.jobs {
+		dropped = append(dropped, job)
+	}
+	<-drained
+
+	q.keyMu.Lock()
+	for key, waiting := range q.active {
+		dropped = append(dropped, waiting...)
+		delete(q.active, key)
+	}
+	q.waiting = 0
+	q.keyMu.Unlock()
+
+	for _, job := range dropped {
+		if job.Abandon != nil {
+			job.Abandon()
+		}
+	}
+	return int(q.processed.Load() - before), len(dropped)
+}
@@ -0,0 +1,50 @@
+package queue
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestKeyedJobsRunInOrder(t *testing.T) {
+	q := New(4, 64)
+	q.Start()
+
+	actions := []string{"opened", "merged", "opened", "merged", "opened", "merged", "opened", "merged"}
+
+	var mu sync.Mutex
+	var ran []string
+	state := ""
+	for i, action := range actions {
+		action := action
+		err := q.Enqueue(Job{
+			ID:  fmt.Sprintf("delivery-%d", i),
+			Key: "repo#1",
+			Run: func() {
+				// Give other workers the chance to overtake if ordering were broken
+				time.Sleep(time.Millisecond)
+				mu.Lock()
+				defer mu.Unlock()
+				ran = append(ran, action)
+				state = action
+			},
+		})
+		if err != nil {
+			t.Fatalf("Enqueue job %d: %v", i, err)
+		}
+	}
+	q.Close()
+
+	if len(ran) != len(actions) {
+		t.Fatalf("ran %d jobs, want %d", len(ran), len(actions))
+	}
+	for i := range actions {
+		if ran[i] != actions[i] {
+			t.Fatalf("job %d ran %q, want %q (order %v)", i, ran[i], actions[i], ran)
+		}
+	}
+	if state != "merged" {
+		t.Errorf("final state %q, want merged", state)
+	}
+}
@@ -0,0 +1,109 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// Policy retries transient failures with exponential backoff and jitter
+type Policy struct {
+	// MaxAttempts is the total number of tries, including the first
+	MaxAttempts int
+	// BaseDelay is the backoff before the second attempt; it doubles each retry
+	BaseDelay time.Duration
+	// MaxDelay caps any single wait, including server-advised delays;
+	// an advised delay longer than this ends retrying immediately
+	MaxDelay time.Duration
+}
+
+// DefaultPolicy is used by API clients unless configured otherwise
+var DefaultPolicy = Policy{MaxAttempts: 3, BaseDelay: time.Second, MaxDelay: 2 * time.Minute}
+
+// retryable is implemented by errors that may succeed if the operation is repeated
+type retryable interface {
+	Retryable() bool
+}
+
+// delayed is implemented by errors that carry a server-advised wait (e.g. Retry-After)
+type delayed interface {
+	RetryAfter() time.Duration
+}
+
+// transientError marks a wrapped error as retryable
+type transientError struct {
+	err error
+}
+
+func (e *transientError) Error() string   { return e.err.Error() }
+func (e *transientError) Unwrap() error   { return e.err }
+func (e *transientError) Retryable() bool { return true }
+
+// Transient marks err as safe to retry
+func Transient(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &transientError{err: err}
+}
+
+// IsRetryable reports whether err (or anything it wraps) is marked retryable
+func IsRetryable(err error) bool {
+	var r retryable
+	return errors.As(err, &r) && r.Retryable()
+}
+
+// Do runs op until it succeeds, returns a non-retryable error, attempts run out or ctx ends
+func (p Policy) Do(ctx context.Context, op func() error) error {
+	attempts := p.MaxAttempts
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if err = op(); err == nil || !IsRetryable(err) || attempt == attempts {
+			return err
+		}
+
+		wait, ok := p.delay(attempt, err)
+		if !ok {
+			return err
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			// Wrap ctx's error so callers can tell the deadline, not the last attempt, ended the call
+			return fmt.Errorf("%w: %w", ctx.Err(), err)
+		case <-timer.C:
+		}
+	}
+	return err
+}
+
+// delay computes the wait before the next attempt; ok is false when the advised wait exceeds MaxDelay
+func (p Policy) delay(attempt int, err error) (time.Duration, bool) {
+	backoff := p.BaseDelay << (attempt - 1)
+	if backoff > 0 {
+		backoff += time.Duration(rand.Int63n(int64(backoff)/2 + 1)) // jitter
+	}
+
+	var d delayed
+	if errors.As(err, &d) {
+		if advised := d.RetryAfter(); advised > backoff {
+			if p.MaxDelay > 0 && advised > p.MaxDelay {
+				return 0, false
+			}
+			return advised, true
+		}
+	}
+
+	if p.MaxDelay > 0 && backoff > p.MaxDelay {
+		backoff = p.MaxDelay
+	}
+	return backoff, true
+}
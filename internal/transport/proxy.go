@@ -0,0 +1,64 @@
+package transport
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+// ConfigureProxy routes http.DefaultTransport — which the GitHub (oauth2), Jira and Slack clients
+// all build on — through an outbound proxy. HTTPS_PROXY, HTTP_PROXY and NO_PROXY are honoured;
+// a non-empty override (API_PROXY_URL) replaces the proxy for both schemes while NO_PROXY
+// still applies. It returns a description of the effective setup for logging.
+func ConfigureProxy(override string) (string, error) {
+	base, ok := http.DefaultTransport.(*http.Transport)
+	if !ok {
+		return "", fmt.Errorf("default transport is %T, not *http.Transport", http.DefaultTransport)
+	}
+
+	cfg := httpproxy.FromEnvironment()
+	if override != "" {
+		if _, err := url.Parse(override); err != nil {
+			return "", fmt.Errorf("invalid value for API_PROXY_URL: %w", err)
+		}
+		cfg.HTTPProxy = override
+		cfg.HTTPSProxy = override
+	}
+
+	proxyFunc := cfg.ProxyFunc()
+	base.Proxy = func(req *http.Request) (*url.URL, error) {
+		return proxyFunc(req.URL)
+	}
+
+	switch {
+	case cfg.HTTPSProxy == "" && cfg.HTTPProxy == "":
+		return "direct (no proxy configured)", nil
+	case override != "":
+		return describe("API_PROXY_URL", redactURL(override), cfg.NoProxy), nil
+	default:
+		return describe("HTTPS_PROXY", redactURL(cfg.HTTPSProxy), cfg.NoProxy), nil
+	}
+}
+
+// describe summarises the proxy in use
+func describe(source, proxy, noProxy string) string {
+	if noProxy == "" {
+		noProxy = os.Getenv("no_proxy")
+	}
+	if noProxy == "" {
+		return fmt.Sprintf("via %s (%s)", proxy, source)
+	}
+	return fmt.Sprintf("via %s (%s, bypassed for %s)", proxy, source, noProxy)
+}
+
+// redactURL hides proxy credentials before the URL is logged
+func redactURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil || u.User == nil {
+		return raw
+	}
+	return u.Redacted()
+}
@@ -0,0 +1,77 @@
+package utils
+
+import (
+	"path"
+	"regexp"
+	"strings"
+)
+
+// GlobSet matches file paths against a list of glob patterns. "*" and "?" stay within one
+// path segment, "**" spans directories, and a pattern without "/" matches the file's base name.
+type GlobSet struct {
+	patterns []*regexp.Regexp
+	baseOnly []bool
+}
+
+// NewGlobSet compiles patterns into a GlobSet, skipping empty entries
+func NewGlobSet(patterns []string) *GlobSet {
+	set := &GlobSet{}
+	for _, pattern := range patterns {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" {
+			continue
+		}
+		set.patterns = append(set.patterns, regexp.MustCompile("^"+globToRegexp(pattern)+"$"))
+		set.baseOnly = append(set.baseOnly, !strings.Contains(pattern, "/"))
+	}
+	return set
+}
+
+// Match reports whether name matches any pattern in the set
+func (s *GlobSet) Match(name string) bool {
+	if s == nil {
+		return false
+	}
+	for i, pattern := range s.patterns {
+		candidate := name
+		if s.baseOnly[i] {
+			candidate = path.Base(name)
+		}
+		if pattern.MatchString(candidate) {
+			return true
+		}
+	}
+	return false
+}
+
+// Empty reports whether the set has no patterns
+func (s *GlobSet) Empty() bool {
+	return s == nil || len(s.patterns) == 0
+}
+
+// globToRegexp translates a glob pattern into an equivalent regular expression
+func globToRegexp(pattern string) string {
+	var b strings.Builder
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; c {
+		case '*':
+			if i+1 < len(pattern) && pattern[i+1] == '*' {
+				i++
+				// "dir/**/x" also matches "dir/x"
+				if i+1 < len(pattern) && pattern[i+1] == '/' {
+					i++
+					b.WriteString("(?:.*/)?")
+				} else {
+					b.WriteString(".*")
+				}
+			} else {
+				b.WriteString("[^/]*")
+			}
+		case '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,42 @@
+package utils
+
+import (
+	"crypto/rand"
+	"fmt"
+	"sync/atomic"
+)
+
+// IDGen generates unique identifiers for correlation and idempotency keys
+type IDGen interface {
+	NewID() string
+}
+
+// UUIDGen generates random RFC 4122 version 4 UUIDs
+type UUIDGen struct{}
+
+// NewID returns a new random UUID
+func (UUIDGen) NewID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic(fmt.Sprintf("utils: failed to read random bytes: %v", err))
+	}
+	b[6] = (b[6] & 0x0f) | 0x40 // version 4
+	b[8] = (b[8] & 0x3f) | 0x80 // RFC 4122 variant
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+// SequentialIDGen generates predictable IDs (prefix-1, prefix-2, ...) for tests
+type SequentialIDGen struct {
+	prefix string
+	next   atomic.Uint64
+}
+
+// NewSequentialIDGen creates a deterministic generator
+func NewSequentialIDGen(prefix string) *SequentialIDGen {
+	return &SequentialIDGen{prefix: prefix}
+}
+
+// NewID returns the next ID in sequence
+func (g *SequentialIDGen) NewID() string {
+	return fmt.Sprintf("%s-%d", g.prefix, g.next.Add(1))
+}
@@ -2,6 +2,7 @@ package utils
 
 import (
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"time"
@@ -10,12 +11,19 @@ import (
 type Logger struct {
 	infoLogger  *log.Logger
 	errorLogger *log.Logger
+	debug       bool
 }
 
 func NewLogger() *Logger {
+	return NewLoggerWithWriters(os.Stdout, os.Stderr)
+}
+
+// NewLoggerWithWriters creates a logger writing info and error lines to the given writers
+// (e.g. bytes.Buffers in tests)
+func NewLoggerWithWriters(info, err io.Writer) *Logger {
 	return &Logger{
-		infoLogger:  log.New(os.Stdout, "  INFO: ", log.LstdFlags),
-		errorLogger: log.New(os.Stderr, " ERROR: ", log.LstdFlags),
+		infoLogger:  log.New(info, "  INFO: ", log.LstdFlags),
+		errorLogger: log.New(err, " ERROR: ", log.LstdFlags),
 	}
 }
 
@@ -27,6 +35,18 @@ func (l *Logger) Error(message string) {
 	l.errorLogger.Printf("%s", message)
 }
 
+// EnableDebug turns on Debug lines, which are dropped by default
+func (l *Logger) EnableDebug() {
+	l.debug = true
+}
+
+// Debug writes a message to the info writer when debug logging is enabled
+func (l *Logger) Debug(message string) {
+	if l.debug {
+		l.infoLogger.Printf("DEBUG: %s", message)
+	}
+}
+
 func (l *Logger) ProductionLog(eventType, details string) {
 	timestamp := time.Now().Format("2006-01-02 15:04:05")
 	l.Info(fmt.Sprintf(" PRODUCTION EVENT [%s] %s: %s", timestamp, eventType, details))
@@ -0,0 +1,10 @@
+package version
+
+// Version is the build version, injected at compile time with
+// -ldflags "-X github_integration/internal/version.Version=v1.2.3"
+var Version = "dev"
+
+// DefaultUserAgent identifies the service's outbound API requests in provider audit logs
+func DefaultUserAgent() string {
+	return "github-jira-integration/" + Version
+}
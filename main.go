@@ -7,16 +7,31 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
+	"text/template"
 	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/joho/godotenv"
 
+	"github_integration/internal/admintoken"
+	"github_integration/internal/config"
+	"github_integration/internal/deadletter"
+	"github_integration/internal/digest"
+	"github_integration/internal/eventlog"
+	"github_integration/internal/events"
 	"github_integration/internal/github"
 	"github_integration/internal/handlers"
+	"github_integration/internal/health"
 	"github_integration/internal/jira"
+	"github_integration/internal/metrics"
+	"github_integration/internal/notifier"
+	"github_integration/internal/retry"
+	"github_integration/internal/tracing"
+	"github_integration/internal/transport"
 	"github_integration/internal/utils"
+	"github_integration/internal/version"
 )
 
 func main() {
@@ -25,76 +40,264 @@ func main() {
 		log.Println("No .env file found, using system environment variables")
 	}
 
-	// Required environment variables
-	githubToken := os.Getenv("GITHUB_TOKEN")
-	githubOrg := os.Getenv("GITHUB_ORG")
-	port := os.Getenv("PORT")
+	// Load service configuration
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Invalid configuration: %v", err)
+	}
+	port := cfg.Port
+
+	// "admin-token [ttl]" prints a signed, time-limited admin token instead of serving
+	if len(os.Args) > 1 && os.Args[1] == "admin-token" {
+		printAdminToken(cfg, os.Args[2:])
+		return
+	}
 
-	if githubToken == "" || githubOrg == "" {
-		log.Fatal("GITHUB_TOKEN and GITHUB_ORG environment variables are required")
+	// Route all outbound API traffic through the configured proxy, if any
+	proxy, err := transport.ConfigureProxy(cfg.APIProxyURL)
+	if err != nil {
+		log.Fatalf("Proxy configuration failed: %v", err)
 	}
+	log.Printf("Outbound API traffic: %s", proxy)
 
-	if port == "" {
-		port = "3000" // Default port
+	// Initialize tracing (no-op unless OTEL_EXPORTER_OTLP_ENDPOINT is set)
+	shutdownTracing, err := tracing.Setup(context.Background())
+	if err != nil {
+		log.Fatalf("Tracing initialization failed: %v", err)
 	}
 
 	// Initialize GitHub client
-	githubClient := github.NewClient(githubToken, githubOrg)
+	var githubClient *github.Client
+	if cfg.AppAuth() {
+		githubClient, err = github.NewAppClient(github.AppCredentials{
+			AppID:          cfg.GitHubAppID,
+			InstallationID: cfg.GitHubInstallationID,
+			PrivateKeyPath: cfg.GitHubAppPrivateKey,
+		}, cfg.GitHubOrg)
+		if err != nil {
+			log.Fatalf("GitHub App client initialization failed: %v", err)
+		}
+	} else {
+		githubClient = github.NewClient(cfg.GitHubToken, cfg.GitHubOrg)
+	}
+
+	githubClient.EnableRepoCache(cfg.GitHubRepoCacheTTL)
+	githubClient.SetDiffIgnoreGlobs(cfg.DiffIgnoreGlobs)
+	githubClient.UsePRDetailsGraphQL(cfg.GitHubPRDetailsGraphQL)
+	githubClient.DiagnoseNotFound(cfg.GitHubDiagnoseNotFound)
+	githubClient.SetUserAgent(cfg.UserAgent)
+	githubRetry := retry.DefaultPolicy
+	githubRetry.MaxAttempts = cfg.GitHubMaxAttempts
+	githubClient.SetRetryPolicy(githubRetry)
+	if cfg.RepoOwnersFile != "" {
+		if err := githubClient.SetRepoOwnersFile(cfg.RepoOwnersFile); err != nil {
+			log.Fatalf("Failed to load repository owners: %v", err)
+		}
+	}
 
 	// Initialize logger
 	logger := utils.NewLogger()
+	if cfg.LogDebug {
+		logger.EnableDebug()
+	}
 
-	// Initialize Jira client (simple version)
-	jiraBaseURL := os.Getenv("JIRA_BASE_URL")
-	jiraEmail := os.Getenv("JIRA_EMAIL")
-	jiraAPIToken := "ATATT3xFfGF04m84BiciY-IwQUwy98p-tyQrO7rl7Q7Gu8xAWK1EQAIwGca_BqdnkNANCp-0rbZVW9Qal5ba07wyAGO_YR13UwyYPmUnhJDj6NpwuOd8HWYrmpY32v607O2aUmYhaD4vP0ELz92it32NGEygTCC9e4uDJTrXCDmCDJ-mYfRCJ6o=6378919E"
+	// Turn missing token permissions into an actionable startup message instead of mid-operation 403s
+	reportCapabilities(githubClient, logger)
 
+	// Initialize Jira client (simple version)
 	var jiraClient *jira.Client
-	var err error
 
-	if jiraBaseURL != "" && jiraEmail != "" && jiraAPIToken != "" {
-		jiraClient, err = jira.NewClient(jiraBaseURL, jiraEmail, jiraAPIToken)
+	if cfg.JiraEnabled() {
+		var summaryTemplate *template.Template
+		if cfg.JiraSummaryTemplate != "" {
+			if summaryTemplate, err = jira.ParseSummaryTemplate(cfg.JiraSummaryTemplate); err != nil {
+				log.Fatalf("Invalid JIRA_SUMMARY_TEMPLATE: %v", err)
+			}
+		}
+		openStatus, _ := cfg.TransitionFor(github.EventPullRequest, "opened")
+		jiraClient, err = jira.NewClient(cfg.JiraBaseURL, cfg.JiraEmail, cfg.JiraAPIToken, jira.Options{
+			ProjectKey:        cfg.JiraProjectKey,
+			LabelPrefix:       cfg.JiraLabelPrefix,
+			LegacyLabelLookup: cfg.JiraLegacyLabelLookup,
+			OpenStatus:        openStatus,
+			EpicLinkField:     cfg.JiraEpicLinkField,
+			DefaultEpic:       cfg.JiraDefaultEpic,
+			FieldDefaults:     cfg.JiraFieldDefaults,
+			RoutedProjects:    cfg.RoutedProjects(),
+			MaxConcurrency:    cfg.JiraMaxConcurrency,
+			StateMachine:      cfg.JiraStateMachine,
+			UserAgent:         cfg.UserAgent,
+			IssueType:         cfg.JiraIssueType,
+			ProjectKeyRules:   projectKeyRules(cfg),
+			ClosedStatus:      cfg.JiraArchivedStatus,
+			IncludePRBody:     cfg.JiraIncludePRBody,
+			SprintField:       cfg.JiraSprintField,
+			SprintBoardID:     cfg.JiraSprintBoardID,
+			Logger:            logger,
+			SummaryPathDepth:  summaryPathDepth(cfg),
+			SummaryTemplate:   summaryTemplate,
+		})
 		if err != nil {
 			log.Printf("Jira client initialization failed: %v (continuing without Jira)", err)
 		} else {
 			logger.Info("Jira integration enabled")
+			if len(cfg.Profiles) > 0 {
+				logger.Info(fmt.Sprintf("Loaded %d per-org configuration profiles", len(cfg.Profiles)))
+			}
+			validateTransitions(jiraClient, cfg, logger)
+			validateIssueTypes(jiraClient, cfg, logger)
+			if cfg.JiraSelfTest {
+				runJiraSelfTest(jiraClient, cfg, logger)
+			}
 		}
 	} else {
 		logger.Info("Jira configuration missing - running without Jira integration")
 	}
 
+	// Initialize alerting and readiness tracking
+	var alerts notifier.Notifier = notifier.NewLogNotifier(logger)
+	if cfg.SlackWebhookURL != "" {
+		alerts = notifier.NewSlackNotifier(cfg.SlackWebhookURL)
+	}
+	readiness := health.NewReadiness()
+
+	// Background check so revoked/expired tokens surface before webhooks start failing
+	backgroundCtx, stopBackground := context.WithCancel(context.Background())
+	defer stopBackground()
+	// (GitHub App installation tokens are minted and refreshed automatically, so only PATs are checked)
+	if !cfg.AppAuth() {
+		tokenChecker := health.NewTokenChecker(githubClient, readiness, alerts, logger, cfg.GitHubTokenCheckInterval)
+		go tokenChecker.Run(backgroundCtx)
+	}
+
 	// Initialize webhook handler with both clients
-	webhookHandler := handlers.NewWebhookHandler(githubClient, jiraClient, logger)
+	webhookHandler := handlers.NewWebhookHandler(githubClient, jiraClient, logger, cfg)
+
+	webhookHandler.SetNotifier(alerts)
+	if cfg.WebhookPendingFile != "" {
+		if err := webhookHandler.SetPendingHooksFile(cfg.WebhookPendingFile); err != nil {
+			log.Fatalf("Failed to load pending webhook registrations: %v", err)
+		}
+	}
+	go webhookHandler.RetryPendingWebhooks(backgroundCtx)
+
+	// Side effects of processed deliveries subscribe to the handler's event bus
+	webhookHandler.Events().Subscribe("metrics", events.RecordMetrics)
+	if cfg.AlertOnFailedEvents {
+		webhookHandler.Events().Subscribe("alerts", events.AlertOnFailure(alerts), events.Async())
+	}
+	if cfg.DeadLetterFile != "" {
+		store, err := deadletter.NewFileStore(cfg.DeadLetterFile)
+		if err != nil {
+			log.Fatalf("Failed to open dead-letter store: %v", err)
+		}
+		webhookHandler.SetDeadLetterStore(store)
+		logger.Info(fmt.Sprintf("Recording failed Jira operations in %s", cfg.DeadLetterFile))
+	}
+	eventSink, err := openEventSink(cfg, logger)
+	if err != nil {
+		log.Fatalf("Failed to open event log: %v", err)
+	}
+	if eventSink != nil {
+		webhookHandler.Events().Subscribe("eventlog", events.WriteEventLog(eventSink, func(err error) {
+			logger.Error(fmt.Sprintf("Failed to write event log: %v", err))
+		}), events.Async())
+	}
+
+	if cfg.StartupCatchUp && jiraClient != nil {
+		go webhookHandler.CatchUpMergedPRs(backgroundCtx, cfg.StartupCatchUpRepos, cfg.StartupCatchUpWindow)
+	}
+
+	if cfg.DailySummaryTime != "" {
+		records, ok := eventSink.(eventlog.Reader)
+		if !ok {
+			log.Fatalf("DAILY_SUMMARY_TIME needs an EVENT_LOG_SINK that can be read back (file or s3)")
+		}
+		scheduleDailySummary(backgroundCtx, cfg, records, jiraClient, logger)
+	}
 
 	// Setup HTTP router
 	router := mux.NewRouter()
+	router.Use(handlers.AccessLog(logger))
+
+	// Every endpoint lives under ROUTE_PREFIX (none by default)
+	routes := router
+	if cfg.RoutePrefix != "" {
+		routes = router.PathPrefix(cfg.RoutePrefix).Subrouter()
+		logger.Info(fmt.Sprintf("Serving all routes under %s", cfg.RoutePrefix))
+	}
+
+	// Webhook routes share one in-flight limit (WEBHOOK_MAX_IN_FLIGHT) for overload protection
+	limitWebhooks := handlers.LimitInFlight(cfg.WebhookMaxInFlight, cfg.WebhookRetryAfter)
 
+	// Unified webhook endpoint - routes org and repo hooks by their target type header
+	// (webhook routes get their own write deadline so slow synchronous processing isn't cut off)
+	routes.HandleFunc("/webhook", limitWebhooks(handlers.WithWriteTimeout(cfg.WebhookWriteTimeout, webhookHandler.HandleWebhook))).Methods("POST")
+
+	// Deprecated split endpoints, kept working for existing hooks
 	// Organization webhook endpoint - receives all org events
-	router.HandleFunc("/webhook/org", webhookHandler.HandleOrgWebhook).Methods("POST")
+	routes.HandleFunc("/webhook/org", limitWebhooks(handlers.WithWriteTimeout(cfg.WebhookWriteTimeout, webhookHandler.HandleOrgWebhook))).Methods("POST")
 
 	// Individual repository webhook endpoint - receives specific repo events
-	router.HandleFunc("/webhook/repo", webhookHandler.HandleRepoWebhook).Methods("POST")
+	routes.HandleFunc("/webhook/repo", limitWebhooks(handlers.WithWriteTimeout(cfg.WebhookWriteTimeout, webhookHandler.HandleRepoWebhook))).Methods("POST")
+
+	// Outcome of a delivery processed asynchronously (WEBHOOK_ASYNC=true); admin-only, as it names repos and issues
+	routes.HandleFunc("/webhook/status/{id}", webhookHandler.RequireAdmin(webhookHandler.HandleWebhookStatus)).Methods("GET")
+
+	// Admin endpoint - create/repair Jira issues for a repository's open PRs
+	routes.HandleFunc("/admin/reconcile/{repo}", webhookHandler.RequireAdmin(webhookHandler.HandleReconcile)).Methods("POST")
+
+	// Admin endpoint - Jira issue and status linked to a PR
+	routes.HandleFunc("/pr/{repo}/{number}/jira", webhookHandler.RequireAdmin(webhookHandler.HandlePRIssue)).Methods("GET")
+
+	// Admin endpoint - effective configuration with secrets redacted
+	routes.HandleFunc("/debug/config", webhookHandler.RequireAdmin(webhookHandler.HandleDebugConfig)).Methods("GET")
+
+	// Admin endpoints - list and replay failed Jira operations
+	routes.HandleFunc("/admin/deadletter", webhookHandler.RequireAdmin(webhookHandler.HandleListDeadLetters)).Methods("GET")
+	routes.HandleFunc("/admin/deadletter/{id}/retry", webhookHandler.RequireAdmin(webhookHandler.HandleRetryDeadLetter)).Methods("POST")
+
+	// Prometheus metrics endpoint
+	routes.HandleFunc("/metrics", metrics.Default.Handler()).Methods("GET")
+
+	// Lightweight JSON counters for deployments without Prometheus
+	routes.HandleFunc("/stats", webhookHandler.HandleStats).Methods("GET")
+	routes.HandleFunc("/admin/stats/reset", webhookHandler.RequireAdmin(webhookHandler.HandleResetStats)).Methods("POST")
+
+	// Current GitHub rate-limit standing (cached briefly)
+	routes.HandleFunc("/ratelimit", webhookHandler.HandleRateLimits).Methods("GET")
 
 	// Health check endpoint
-	router.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
+	routes.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
 		w.Write([]byte("GitHub Organization Microservice is running!"))
 	}).Methods("GET")
 
+	// Readiness endpoint - fails while a background check reports a problem
+	routes.HandleFunc("/ready", func(w http.ResponseWriter, r *http.Request) {
+		ready, reasons := readiness.Ready()
+		if !ready {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte("Not ready: " + strings.Join(reasons, "; ")))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("Ready"))
+	}).Methods("GET")
+
 	// Setup HTTP server
 	server := &http.Server{
 		Addr:         ":" + port,
 		Handler:      router,
-		ReadTimeout:  15 * time.Second,
-		WriteTimeout: 15 * time.Second,
+		ReadTimeout:  cfg.HTTPReadTimeout,
+		WriteTimeout: cfg.HTTPWriteTimeout,
 		IdleTimeout:  60 * time.Second,
 	}
 
 	// Start server in goroutine
 	go func() {
-		logger.Info(fmt.Sprintf("GitHub Organization Microservice starting on port %s", port))
-		logger.Info(fmt.Sprintf("Organization webhook URL: http://localhost:%s/webhook/org", port))
-		logger.Info(fmt.Sprintf("Repository webhook URL: http://localhost:%s/webhook/repo", port))
+		logger.Info(fmt.Sprintf("GitHub Organization Microservice %s starting on port %s", version.Version, port))
+		logger.Info(fmt.Sprintf("Webhook URL (org and repo hooks): http://localhost:%s%s/webhook", port, cfg.RoutePrefix))
 
 		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 			log.Fatalf("Server failed to start: %v", err)
@@ -107,6 +310,7 @@ func main() {
 	<-quit
 
 	logger.Info("Shutting down server...")
+	stopBackground()
 
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
@@ -115,5 +319,241 @@ func main() {
 		log.Fatalf("Server forced to shutdown: %v", err)
 	}
 
+	// Drain deliveries already accepted by the async worker pool within the shutdown deadline
+	webhookHandler.Shutdown(ctx)
+
+	// The bus is closed now, so no more records arrive; upload or close what the event log holds
+	if eventSink != nil {
+		if err := eventSink.Close(); err != nil {
+			logger.Error(fmt.Sprintf("Failed to flush event log: %v", err))
+		}
+	}
+
+	// Flush any buffered spans
+	if err := shutdownTracing(ctx); err != nil {
+		logger.Error(fmt.Sprintf("Failed to flush traces: %v", err))
+	}
+
 	logger.Info("Server gracefully stopped")
 }
+
+// validateTransitions warns about configured statuses that don't exist in the Jira project, then
+// checks the rest are reachable as transitions
+func validateTransitions(jiraClient *jira.Client, cfg *config.Config, logger *utils.Logger) {
+	available, err := jiraClient.ProjectStatuses()
+	if err != nil {
+		logger.Error(fmt.Sprintf("Could not validate Jira transition config: %v", err))
+		return
+	}
+
+	known := make(map[string]bool, len(available))
+	for _, status := range available {
+		known[status] = true
+	}
+	for _, status := range cfg.TransitionStatuses() {
+		if !known[status] {
+			logger.Error(fmt.Sprintf("Configured Jira status %q does not exist in project %s (available: %s)",
+				status, cfg.JiraProjectKey, strings.Join(available, ", ")))
+		}
+	}
+
+	validateWorkflow(jiraClient, cfg, logger)
+}
+
+// projectKeyRules returns the repo -> project key derivation rules, or nil unless JIRA_PROJECT_FROM_REPO is set
+func projectKeyRules(cfg *config.Config) *jira.ProjectKeyRules {
+	if !cfg.JiraProjectFromRepo {
+		return nil
+	}
+	return &jira.ProjectKeyRules{
+		Overrides:     cfg.JiraProjectKeyOverrides,
+		StripPrefixes: cfg.JiraProjectKeyStripPrefix,
+		Acronym:       cfg.JiraProjectKeyAcronym,
+	}
+}
+
+// validateIssueTypes warns about the default and topic issue types the Jira project doesn't have
+func validateIssueTypes(jiraClient *jira.Client, cfg *config.Config, logger *utils.Logger) {
+	available, err := jiraClient.ProjectIssueTypes()
+	if err != nil {
+		logger.Error(fmt.Sprintf("Could not validate Jira issue types: %v", err))
+		return
+	}
+
+	check := func(issueType, source string) {
+		if _, ok, _ := jiraClient.ResolveIssueType(issueType); !ok {
+			logger.Error(fmt.Sprintf("Configured Jira issue type %q (%s) does not exist in project %s (available: %s)",
+				issueType, source, cfg.JiraProjectKey, strings.Join(available, ", ")))
+		}
+	}
+	check(cfg.JiraIssueType, "JIRA_ISSUE_TYPE")
+	for topic, issueType := range cfg.JiraTopicIssueTypes {
+		check(issueType, "topic "+topic)
+	}
+	for commitType, issueType := range cfg.JiraCommitTypeIssueTypes {
+		check(issueType, "commit type "+commitType)
+	}
+}
+
+// validateWorkflow warns when configured statuses can't be reached by a transition from a sample issue
+func validateWorkflow(jiraClient *jira.Client, cfg *config.Config, logger *utils.Logger) {
+	issueKey, targets, err := jiraClient.SampleTransitions()
+	if err != nil {
+		logger.Error(fmt.Sprintf("Could not dry-validate Jira workflow: %v", err))
+		return
+	}
+
+	reachable := make(map[string]bool, len(targets))
+	for _, target := range targets {
+		reachable[target] = true
+	}
+
+	var missing []string
+	for _, status := range cfg.TransitionStatuses() {
+		if status != jiraClient.OpenStatus() && !reachable[status] {
+			missing = append(missing, status)
+		}
+	}
+
+	if len(missing) == 0 {
+		logger.Info(fmt.Sprintf("Jira workflow validated against %s: all configured statuses are reachable", issueKey))
+		return
+	}
+	logger.Error(fmt.Sprintf("Jira workflow check: no transition from %s to %s (available transitions: %s)",
+		issueKey, strings.Join(missing, ", "), strings.Join(targets, ", ")))
+}
+
+// runJiraSelfTest creates a throwaway issue, walks it through the configured statuses and deletes it,
+// logging each step so permission or workflow problems show up at boot
+func runJiraSelfTest(jiraClient *jira.Client, cfg *config.Config, logger *utils.Logger) {
+	issue, err := jiraClient.CreateSelfTestIssue()
+	if err != nil {
+		logger.Error(fmt.Sprintf("Jira self-test: create failed: %v", err))
+		return
+	}
+	logger.Info(fmt.Sprintf("Jira self-test: created %s in project %s", issue.Key, cfg.JiraProjectKey))
+
+	defer func() {
+		if err := jiraClient.DeleteIssue(issue.Key); err != nil {
+			logger.Error(fmt.Sprintf("Jira self-test: delete of %s failed: %v", issue.Key, err))
+			return
+		}
+		logger.Info(fmt.Sprintf("Jira self-test: deleted %s", issue.Key))
+	}()
+
+	// Start with the open status, as real PR issues do, then try every other configured status
+	statuses := []string{jiraClient.OpenStatus()}
+	for _, status := range cfg.TransitionStatuses() {
+		if status != jiraClient.OpenStatus() {
+			statuses = append(statuses, status)
+		}
+	}
+	for _, status := range statuses {
+		if err := jiraClient.MoveIssueToStatus(issue.Key, status); err != nil {
+			logger.Error(fmt.Sprintf("Jira self-test: transition of %s to %s failed: %v", issue.Key, status, err))
+			continue
+		}
+		logger.Info(fmt.Sprintf("Jira self-test: moved %s to %s", issue.Key, status))
+	}
+}
+
+// reportCapabilities logs which GitHub operations the configured credentials can perform
+func reportCapabilities(githubClient *github.Client, logger *utils.Logger) {
+	report, err := githubClient.CheckCapabilities()
+	if err != nil {
+		logger.Error(fmt.Sprintf("Could not check GitHub token capabilities: %v", err))
+		return
+	}
+
+	if report.Scopes != nil {
+		logger.Info(fmt.Sprintf("GitHub token scopes: %s", strings.Join(report.Scopes, ", ")))
+	}
+	for _, check := range report.Checks {
+		if check.OK() {
+			logger.Info(fmt.Sprintf("GitHub capability OK: %s", check.Name))
+		} else {
+			logger.Error(fmt.Sprintf("GitHub capability MISSING: %s (%v)", check.Name, check.Err))
+		}
+	}
+	if missing := report.Missing(); len(missing) > 0 {
+		logger.Error(fmt.Sprintf("GitHub token lacks %d capabilities (%s); related features will fail until it is granted them",
+			len(missing), strings.Join(missing, ", ")))
+	}
+}
+
+// openEventSink builds the event log sink selected by EVENT_LOG_SINK, or nil when disabled
+func openEventSink(cfg *config.Config, logger *utils.Logger) (eventlog.EventSink, error) {
+	switch cfg.EventLogSink {
+	case "file":
+		logger.Info(fmt.Sprintf("Writing the event log to %s", cfg.EventLogFile))
+		return eventlog.NewFileSink(cfg.EventLogFile, int64(cfg.EventLogMaxBytes), cfg.EventLogBackups)
+	case "s3":
+		logger.Info(fmt.Sprintf("Writing the event log to s3://%s/%s", cfg.EventLogS3Bucket, cfg.EventLogS3Prefix))
+		return eventlog.NewS3Sink(eventlog.S3Options{
+			Bucket:          cfg.EventLogS3Bucket,
+			Region:          cfg.EventLogS3Region,
+			Prefix:          cfg.EventLogS3Prefix,
+			Endpoint:        cfg.EventLogS3Endpoint,
+			AccessKeyID:     cfg.AWSAccessKeyID,
+			SecretAccessKey: cfg.AWSSecretAccessKey,
+			SessionToken:    cfg.AWSSessionToken,
+			FlushInterval:   cfg.EventLogS3FlushInterval,
+			OnError: func(err error) {
+				logger.Error(fmt.Sprintf("Failed to upload event log: %v", err))
+			},
+		})
+	}
+	return nil, nil
+}
+
+// summaryPathDepth is how many directory levels prefix issue summaries (0 when prefixing is off)
+func summaryPathDepth(cfg *config.Config) int {
+	if !cfg.JiraSummaryPathPrefix {
+		return 0
+	}
+	return cfg.JiraSummaryPathDepth
+}
+
+// scheduleDailySummary posts a digest of the event log every day at DAILY_SUMMARY_TIME
+func scheduleDailySummary(ctx context.Context, cfg *config.Config, records eventlog.Reader, jiraClient *jira.Client, logger *utils.Logger) {
+	var destinations []notifier.Notifier
+	if cfg.DailySummarySlack {
+		destinations = append(destinations, notifier.NewSlackNotifier(cfg.SlackWebhookURL))
+	}
+	if cfg.DailySummaryJiraIssue != "" {
+		if jiraClient == nil {
+			logger.Error("DAILY_SUMMARY_JIRA_ISSUE is set but Jira is not configured - the summary won't be posted to Jira")
+		} else {
+			destinations = append(destinations, notifier.Func(func(title, message string) error {
+				return jiraClient.AddComment(cfg.DailySummaryJiraIssue, fmt.Sprintf("*%s*\n%s\n%s", title, message,
+					handlers.JiraCommentMarker(cfg.CommentMarker, cfg.DailySummaryJiraIssue)))
+			}))
+		}
+	}
+
+	var closed digest.ClosedCounter
+	if jiraClient != nil {
+		closed = jiraClient.CountResolvedSince
+	}
+
+	at, _ := time.Parse("15:04", cfg.DailySummaryTime)
+	go digest.NewScheduler(records, closed, at.Hour(), at.Minute(), destinations, logger).Run(ctx)
+	logger.Info(fmt.Sprintf("Posting a daily activity summary at %s", cfg.DailySummaryTime))
+}
+
+// printAdminToken writes an admin bearer token signed with ADMIN_SIGNING_KEY, valid for the
+// duration given as the first argument (default 15m)
+func printAdminToken(cfg *config.Config, args []string) {
+	if cfg.AdminSigningKey == "" {
+		log.Fatal("ADMIN_SIGNING_KEY is not set")
+	}
+	ttl := 15 * time.Minute
+	if len(args) > 0 {
+		parsed, err := time.ParseDuration(args[0])
+		if err != nil || parsed <= 0 {
+			log.Fatalf("Invalid token lifetime %q: use a positive duration such as 30m", args[0])
+		}
+		ttl = parsed
+	}
+	fmt.Println(admintoken.Generate([]byte(cfg.AdminSigningKey), ttl, time.Now()))
+}